@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	_ "spy-cat-agency/docs"
@@ -12,8 +14,14 @@ import (
 	"spy-cat-agency/internal/api/http/routes"
 	"spy-cat-agency/internal/application/services"
 	"spy-cat-agency/internal/infrastructure/database"
+	"spy-cat-agency/internal/infrastructure/eventbus"
+	"spy-cat-agency/internal/infrastructure/external"
 	"spy-cat-agency/internal/infrastructure/mock_data"
+	"spy-cat-agency/internal/infrastructure/outbox"
+	"spy-cat-agency/internal/infrastructure/ratelimit"
 	"spy-cat-agency/internal/infrastructure/repositories"
+	"spy-cat-agency/internal/infrastructure/tokens"
+	"spy-cat-agency/internal/infrastructure/webhooks"
 	"spy-cat-agency/pkg/validator"
 
 	"github.com/joho/godotenv"
@@ -26,6 +34,10 @@ import (
 // @description A simple API for managing spy cats, missions, and targets
 // @host localhost:3001
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Send `Bearer <token>` with a token from POST /api/v1/auth/login, or `ApiKey <key>` with a key from POST /api/v1/agency/api-keys
 func main() {
 	_ = godotenv.Load()
 
@@ -51,30 +63,113 @@ func main() {
 		log.Fatalf("Failed to run auto-migration: %v", err)
 	}
 
-	mockService := mock_data.NewMockDataService(db)
+	mockService := mock_data.NewMockDataService(db, getEnv("AUTH_SEED_CAT_PASSWORD", ""))
 	if err := mockService.WipeAndSeedData(); err != nil {
 		log.Fatalf("Failed to initialize mock data: %v", err)
 	}
 	log.Println("Mock data initialized successfully")
 
 	catRepo := repositories.NewCatRepository(db)
-	missionRepo := repositories.NewMissionRepository(db.DB).(*repositories.MissionRepository)
-	targetRepo := repositories.NewTargetRepository(db.DB).(*repositories.TargetRepository)
+	missionRepo := repositories.NewMissionRepository(db.DB)
+	targetRepo := repositories.NewTargetRepository(db.DB)
+	outboxRepo := repositories.NewOutboxRepository(db.DB)
+	webhookRepo := repositories.NewWebhookRepository(db.DB)
+	searchRepo := repositories.NewSearchRepository(db.DB)
+	exportRepo := repositories.NewExportRepository(db.DB)
+	credentialRepo := repositories.NewCredentialRepository(db.DB)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db.DB)
+	auditRepo := repositories.NewAuditRepository(db.DB)
+
+	eventBus := eventbus.NewBus()
+	eventBus.Subscribe(eventbus.LogSubscriber())
+
+	missionService := services.NewMissionService(db, missionRepo, targetRepo, catRepo, outboxRepo, auditRepo, eventBus)
+	catService := services.NewCatService(db, catRepo, outboxRepo, auditRepo, external.NewBreedService(), eventBus)
+	webhookService := services.NewWebhookService(db, webhookRepo, auditRepo)
+	searchService := services.NewSearchService(searchRepo)
+	exportService := services.NewExportService(exportRepo)
+	apiKeyService := services.NewAPIKeyService(db, apiKeyRepo, auditRepo)
+	auditService := services.NewAuditService(auditRepo)
+	outboxService := services.NewOutboxService(db, outboxRepo, auditRepo)
+
+	tokenIssuer, err := tokens.NewJWTIssuer(tokens.Config{
+		Secret: []byte(getEnv("JWT_SECRET", "")),
+		Issuer: getEnv("JWT_ISSUER", "spy-cat-agency"),
+		TTL:    time.Duration(getEnvInt("JWT_TTL_MINUTES", 60)) * time.Minute,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure access tokens: %v", err)
+	}
+	authService, err := services.NewAuthService(db, credentialRepo, catRepo, auditRepo, tokenIssuer)
+	if err != nil {
+		log.Fatalf("Failed to create auth service: %v", err)
+	}
+	if password := getEnv("AUTH_STAFF_PASSWORD", ""); password != "" {
+		if err := authService.EnsureDirector(context.Background(), getEnv("AUTH_STAFF_USERNAME", "director"), password); err != nil {
+			log.Fatalf("Failed to create director login: %v", err)
+		}
+	}
 
-	missionService := services.NewMissionService(db, missionRepo, targetRepo, catRepo.(*repositories.CatRepository))
+	outboxConfig := outbox.DefaultConfig()
+	outboxConfig.PollInterval = time.Duration(getEnvInt("OUTBOX_POLL_INTERVAL_SECONDS", 2)) * time.Second
+	outboxConfig.MaxAttempts = int32(getEnvInt("OUTBOX_MAX_ATTEMPTS", 10))
+	dispatcher := outbox.NewDispatcher(outboxRepo, outboxConfig, outbox.LogSink{}, webhooks.NewSink(webhookRepo, nil))
 
-	catHandler := handlers.NewCatHandler(catRepo)
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	go dispatcher.Run(dispatchCtx)
+
+	catHandler := handlers.NewCatHandler(catService)
 	missionHandler := handlers.NewMissionHandler(missionService)
+	outboxHandler := handlers.NewOutboxHandler(outboxService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	authHandler := handlers.NewAuthHandler(authService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+
+	rateLimits := ratelimit.DefaultConfig()
+	for group := range rateLimits.Limits {
+		value := getEnv("RATE_LIMIT_"+strings.ToUpper(group), "")
+		if value == "" {
+			continue
+		}
+		limit, err := ratelimit.ParseLimit(value)
+		if err != nil {
+			log.Fatalf("Invalid rate limit for %s: %v", group, err)
+		}
+		rateLimits.Limits[group] = limit
+	}
+	limiter := ratelimit.NewLimiter(rateLimits)
+
+	var trustedProxies []string
+	if value := getEnv("TRUSTED_PROXIES", ""); value != "" {
+		trustedProxies = strings.Split(value, ",")
+	}
+	ipExtractor, err := custommw.IPExtractor(trustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	e := echo.New()
+	e.IPExtractor = ipExtractor
 
 	e.Validator = validator.NewValidator()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
 
 	e.Use(custommw.LoggingMiddleware())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
-
-	routes.SetupRoutes(e, catHandler, missionHandler)
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{"ETag", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
+	}))
+	e.Use(custommw.RateLimitByIP(limiter, ratelimit.GroupIP))
+	e.Use(custommw.Authenticate(custommw.Schemes{
+		"Bearer": authService,
+		"ApiKey": apiKeyService,
+	}))
+
+	routes.SetupRoutes(e, limiter, catHandler, missionHandler, outboxHandler, webhookHandler, searchHandler, exportHandler, authHandler, apiKeyHandler, auditHandler)
 
 	port := getEnv("PORT", "8080")
 	log.Printf("Starting server on port %s", port)
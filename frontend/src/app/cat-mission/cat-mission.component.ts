@@ -226,7 +226,7 @@ export class CatMissionComponent implements OnInit, OnChanges {
       })
       .catch(error => {
         console.error('Error updating target status:', error);
-        this.error = error.details || 'Failed to update target status. Please try again.';
+        this.error = error.detail || 'Failed to update target status. Please try again.';
         this.updating[target.id!] = false;
         
         // Reload mission to get fresh data
@@ -265,7 +265,7 @@ export class CatMissionComponent implements OnInit, OnChanges {
       })
       .catch(error => {
         console.error('Error updating target notes:', error);
-        this.error = error.details || 'Failed to update target notes. Please try again.';
+        this.error = error.detail || 'Failed to update target notes. Please try again.';
         this.updating[target.id!] = false;
         
         // Reload mission to get fresh data
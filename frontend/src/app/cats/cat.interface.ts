@@ -22,7 +22,7 @@ export interface UpdateSalaryRequest {
 
 export interface CatsResponse {
   cats: SpyCat[];
-  breeds: string[];
+  breeds?: string[];
   total: number;
   limit: number;
   offset: number;
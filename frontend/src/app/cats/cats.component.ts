@@ -206,7 +206,6 @@ export class CatsComponent implements OnInit {
     this.catService.getAllCats().subscribe({
       next: (response: CatsResponse) => {
         this.cats = response.cats || [];
-        this.breeds = response.breeds || [];
         this.isLoading = false;
       },
       error: (error) => {
@@ -246,13 +245,13 @@ export class CatsComponent implements OnInit {
         
         // Extract detailed validation messages from backend response
         if (error.status === 400 && error.error) {
-          if (error.error.details) {
-            errorMessage = `❌ ${error.error.details}`;
-          } else if (error.error.error) {
-            errorMessage = `❌ ${error.error.error}`;
+          if (error.error.detail) {
+            errorMessage = `❌ ${error.error.detail}`;
+          } else if (error.error.title) {
+            errorMessage = `❌ ${error.error.title}`;
           }
-        } else if (error.error && error.error.details) {
-          errorMessage = `❌ ${error.error.details}`;
+        } else if (error.error && error.error.detail) {
+          errorMessage = `❌ ${error.error.detail}`;
         }
         
         this.showMessage(errorMessage, 'error');
@@ -291,13 +290,13 @@ export class CatsComponent implements OnInit {
         
         // Extract detailed validation messages from backend response
         if (error.status === 400 && error.error) {
-          if (error.error.details) {
-            errorMessage = `❌ ${error.error.details}`;
-          } else if (error.error.error) {
-            errorMessage = `❌ ${error.error.error}`;
+          if (error.error.detail) {
+            errorMessage = `❌ ${error.error.detail}`;
+          } else if (error.error.title) {
+            errorMessage = `❌ ${error.error.title}`;
           }
-        } else if (error.error && error.error.details) {
-          errorMessage = `❌ ${error.error.details}`;
+        } else if (error.error && error.error.detail) {
+          errorMessage = `❌ ${error.error.detail}`;
         }
         
         this.showMessage(errorMessage, 'error');
@@ -322,10 +321,10 @@ export class CatsComponent implements OnInit {
           let errorMessage = '❌ Failed to terminate spy cat. Please try again.';
           
           // Check if it's a conflict error (cat assigned to mission)
-          if (error.status === 409 && error.error && error.error.details) {
-            errorMessage = `❌ ${error.error.details}`;
-          } else if (error.error && error.error.details) {
-            errorMessage = `❌ ${error.error.details}`;
+          if (error.status === 409 && error.error && error.error.detail) {
+            errorMessage = `❌ ${error.error.detail}`;
+          } else if (error.error && error.error.detail) {
+            errorMessage = `❌ ${error.error.detail}`;
           }
           
           this.showMessage(errorMessage, 'error');
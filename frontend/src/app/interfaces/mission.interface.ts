@@ -44,3 +44,24 @@ export interface CreateMissionRequest {
   end_date?: Date | string;
   targets: CreateTargetRequest[]; // Now required (min 1, max 3)
 }
+
+export interface MissionListResponse {
+  missions: Mission[];
+  total: number;
+  limit: number;
+  offset: number;
+}
+
+export interface MissionQuery {
+  status?: 'draft' | 'assigned' | 'active' | 'completed' | 'aborted';
+  completed?: boolean;
+  cat_id?: number;
+  country?: string;
+  from?: string;
+  to?: string;
+  name?: string;
+  sort?: 'id' | 'name' | 'status' | 'start_date' | 'end_date' | 'created_at';
+  order?: 'asc' | 'desc';
+  limit?: number;
+  offset?: number;
+}
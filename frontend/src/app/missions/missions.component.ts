@@ -1,5 +1,5 @@
 import { Component, OnInit } from '@angular/core';
-import { Mission, CreateMissionRequest, CreateTargetRequest } from '../interfaces/mission.interface';
+import { Mission, CreateMissionRequest, CreateTargetRequest, MissionListResponse } from '../interfaces/mission.interface';
 import { MissionService } from '../services/mission.service';
 
 @Component({
@@ -9,6 +9,9 @@ import { MissionService } from '../services/mission.service';
 })
 export class MissionsComponent implements OnInit {
   missions: Mission[] = [];
+  total = 0;
+  pageSize = 20;
+  offset = 0;
   loading = false;
   error: string | null = null;
   showCreateForm = false;
@@ -44,9 +47,10 @@ export class MissionsComponent implements OnInit {
     this.loading = true;
     this.error = null;
     
-    this.missionService.getMissions().subscribe({
-      next: (missions: Mission[]) => {
-        this.missions = missions;
+    this.missionService.getMissions({ limit: this.pageSize, offset: this.offset }).subscribe({
+      next: (page: MissionListResponse) => {
+        this.missions = page.missions;
+        this.total = page.total;
         this.loading = false;
       },
       error: (error) => {
@@ -57,6 +61,28 @@ export class MissionsComponent implements OnInit {
     });
   }
 
+  get hasPreviousPage(): boolean {
+    return this.offset > 0;
+  }
+
+  get hasNextPage(): boolean {
+    return this.offset + this.pageSize < this.total;
+  }
+
+  previousPage(): void {
+    if (this.hasPreviousPage) {
+      this.offset = Math.max(0, this.offset - this.pageSize);
+      this.loadMissions();
+    }
+  }
+
+  nextPage(): void {
+    if (this.hasNextPage) {
+      this.offset += this.pageSize;
+      this.loadMissions();
+    }
+  }
+
   toggleCreateForm(): void {
     this.showCreateForm = !this.showCreateForm;
     if (!this.showCreateForm) {
@@ -78,14 +104,15 @@ export class MissionsComponent implements OnInit {
       this.missionService.createMission(missionData).subscribe({
         next: (mission) => {
           this.missions.push(mission);
+          this.total++;
           this.resetForm();
           this.showCreateForm = false;
           this.loading = false;
         },
         error: (error) => {
           console.error('Error creating mission:', error);
-          if (error.error && error.error.details) {
-            this.error = `Failed to create mission: ${error.error.details}`;
+          if (error.error && error.error.detail) {
+            this.error = `Failed to create mission: ${error.error.detail}`;
           } else {
             this.error = 'Failed to create mission. Please try again.';
           }
@@ -107,6 +134,7 @@ export class MissionsComponent implements OnInit {
       this.missionService.deleteMission(mission.id).subscribe({
         next: () => {
           this.missions = this.missions.filter(m => m.id !== mission.id);
+          this.total--;
           this.loading = false;
         },
         error: (error) => {
@@ -253,13 +281,13 @@ export class MissionsComponent implements OnInit {
         
         // Extract detailed validation messages from backend response
         if (error.status === 400 && error.error) {
-          if (error.error.details) {
-            errorMessage = `${error.error.details}`;
-          } else if (error.error.error) {
-            errorMessage = `${error.error.error}`;
+          if (error.error.detail) {
+            errorMessage = `${error.error.detail}`;
+          } else if (error.error.title) {
+            errorMessage = `${error.error.title}`;
           }
-        } else if (error.error && error.error.details) {
-          errorMessage = `${error.error.details}`;
+        } else if (error.error && error.error.detail) {
+          errorMessage = `${error.error.detail}`;
         }
         
         this.error = errorMessage;
@@ -322,8 +350,8 @@ export class MissionsComponent implements OnInit {
       },
       error: (error) => {
         console.error('Error adding target:', error);
-        if (error.error && error.error.details) {
-          this.error = `Failed to add target: ${error.error.details}`;
+        if (error.error && error.error.detail) {
+          this.error = `Failed to add target: ${error.error.detail}`;
         } else {
           this.error = 'Failed to add target. Please try again.';
         }
@@ -352,8 +380,8 @@ export class MissionsComponent implements OnInit {
         },
         error: (error) => {
           console.error('Error deleting target:', error);
-          if (error.error && error.error.details) {
-            this.error = `Failed to delete target: ${error.error.details}`;
+          if (error.error && error.error.detail) {
+            this.error = `Failed to delete target: ${error.error.detail}`;
           } else {
             this.error = 'Failed to delete target. Please try again.';
           }
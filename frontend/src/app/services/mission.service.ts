@@ -1,7 +1,7 @@
 import { Injectable } from '@angular/core';
-import { HttpClient } from '@angular/common/http';
+import { HttpClient, HttpParams } from '@angular/common/http';
 import { Observable } from 'rxjs';
-import { Mission, CreateMissionRequest } from '../interfaces/mission.interface';
+import { Mission, CreateMissionRequest, MissionListResponse, MissionQuery } from '../interfaces/mission.interface';
 
 @Injectable({
   providedIn: 'root'
@@ -11,9 +11,15 @@ export class MissionService {
 
   constructor(private http: HttpClient) { }
 
-  // Get all missions
-  getMissions(): Observable<Mission[]> {
-    return this.http.get<Mission[]>(this.apiUrl);
+  // Get a page of missions matching the query
+  getMissions(query: MissionQuery = {}): Observable<MissionListResponse> {
+    let params = new HttpParams();
+    Object.entries(query).forEach(([key, value]) => {
+      if (value !== undefined && value !== null && value !== '') {
+        params = params.set(key, String(value));
+      }
+    });
+    return this.http.get<MissionListResponse>(this.apiUrl, { params });
   }
 
   // Get a specific mission by ID
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/application/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	apiKeyService     services.APIKeyService
+	validationService *services.ValidationService
+}
+
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService:     apiKeyService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// CreateAPIKey mints an API key for a service-to-service caller
+// @Summary Create an API key
+// @Description Mint a key limited to the given scopes. Send it as `Authorization: ApiKey <key>`. The key is only returned here.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body dto.CreateAPIKeyRequest true "Name, scopes and optional expiry"
+// @Success 201 {object} dto.APIKeyResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeys returns every API key, including revoked ones
+// @Summary List API keys
+// @Tags api-keys
+// @Produce json
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey stops an API key from working
+// @Summary Revoke an API key
+// @Description The key stays listed with its revocation time. Revoking it again changes nothing.
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	id, err := h.validationService.ValidateID(c.Param("id"), "API key ID")
+	if err != nil {
+		return err
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, key)
+}
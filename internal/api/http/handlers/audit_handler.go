@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditService      services.AuditService
+	validationService *services.ValidationService
+}
+
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService:      auditService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// ListAuditEntries returns the audit log, newest first
+// @Summary List audit log entries
+// @Description Who changed which cat, mission or target, with the entity before and after the change
+// @Tags audit
+// @Produce json
+// @Param entity_type query string false "Entity type" Enums(cat, mission, target, credential, api_key, webhook, outbox_event)
+// @Param entity_id query int false "Entity ID; needs entity_type"
+// @Param actor query string false "Username of the staff member or spy cat, or API key name"
+// @Param from query string false "Changes on or after this time (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "Changes on or before this time (YYYY-MM-DD or RFC3339)"
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.AuditEntryResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/audit [get]
+func (h *AuditHandler) ListAuditEntries(c echo.Context) error {
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		return invalidParameter(err)
+	}
+
+	filter.Limit, filter.Offset, err = h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	entries, err := h.auditService.ListAuditEntries(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+func auditFilterFromQuery(c echo.Context) (interfaces.AuditFilter, error) {
+	var filter interfaces.AuditFilter
+
+	if value := c.QueryParam("entity_type"); value != "" {
+		entityType := entities.AuditEntityType(value)
+		if !entityType.IsValid() {
+			return filter, fmt.Errorf("entity_type must be one of: cat, mission, target, credential, api_key, webhook, outbox_event")
+		}
+		filter.EntityType = &entityType
+	}
+
+	if value := c.QueryParam("entity_id"); value != "" {
+		if filter.EntityType == nil {
+			return filter, fmt.Errorf("entity_id needs entity_type")
+		}
+		entityID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || entityID <= 0 {
+			return filter, fmt.Errorf("entity_id must be a positive integer")
+		}
+		filter.EntityID = &entityID
+	}
+
+	if value := strings.TrimSpace(c.QueryParam("actor")); value != "" {
+		filter.Actor = &value
+	}
+
+	if value := c.QueryParam("from"); value != "" {
+		from, _, err := parseQueryDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("from: %w", err)
+		}
+		filter.From = &from
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, dateOnly, err := parseQueryDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("to: %w", err)
+		}
+		if dateOnly {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return filter, fmt.Errorf("to must not be before from")
+	}
+
+	return filter, nil
+}
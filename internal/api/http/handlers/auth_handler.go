@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/application/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	authService       services.AuthService
+	validationService *services.ValidationService
+}
+
+func NewAuthHandler(authService services.AuthService) *AuthHandler {
+	return &AuthHandler{
+		authService:       authService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// Login exchanges a username and password for an access token
+// @Summary Log in
+// @Description Log in as agency staff or as a spy cat. Send the returned token as `Authorization: Bearer <token>`.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "Username and password"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Router /api/v1/auth/login [post]
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req dto.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	response, err := h.authService.Login(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// SetCatCredentials sets the login of a spy cat
+// @Summary Set spy cat login
+// @Description Create or replace the username and password a spy cat uses for its field routes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Param credentials body dto.CatCredentialsRequest true "New username and password"
+// @Success 200 {object} dto.CredentialResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats/{id}/credentials [put]
+func (h *AuthHandler) SetCatCredentials(c echo.Context) error {
+	id, err := h.validationService.ValidateCatID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CatCredentialsRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	credential, err := h.authService.SetCatCredentials(c.Request().Context(), id, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, credential)
+}
+
+// CreateStaff adds an agency staff login
+// @Summary Create staff login
+// @Description Create a login for a director, handler or finance officer
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param staff body dto.CreateStaffRequest true "Username, password and role"
+// @Success 201 {object} dto.CredentialResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/staff [post]
+func (h *AuthHandler) CreateStaff(c echo.Context) error {
+	var req dto.CreateStaffRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	credential, err := h.authService.CreateStaff(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, credential)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"spy-cat-agency/internal/application/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	maxCatImportRows  = 1000
+	maxCatImportBytes = 1 << 20
+)
+
+// ImportCats creates spy cats in bulk
+// @Summary Import spy cats
+// @Description Create many spy cats from a CSV file (header: name,breed,years_of_experience,salary) or a JSON array of cats. Every row is validated like POST /agency/cats, with breeds checked against the cached TheCatAPI list. In atomic mode (default) nothing is created unless every row is valid; in partial mode valid rows are created and the rest reported. With dry_run=true rows are only validated.
+// @Tags cats
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param cats body []dto.CreateCatRequest true "Cats to import (JSON array or CSV)"
+// @Param mode query string false "Import mode" Enums(atomic, partial) default(atomic)
+// @Param dry_run query bool false "Validate only" default(false)
+// @Success 200 {object} dto.CatImportResponse "Dry run report"
+// @Success 201 {object} dto.CatImportResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 413 {object} dto.ProblemResponse
+// @Failure 415 {object} dto.ProblemResponse
+// @Failure 422 {object} dto.CatImportResponse "No cat was created"
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats/import [post]
+func (h *CatHandler) ImportCats(c echo.Context) error {
+	mode := strings.ToLower(c.QueryParam("mode"))
+	if mode == "" {
+		mode = "atomic"
+	}
+	if mode != "atomic" && mode != "partial" {
+		return services.ErrInvalidParameter.WithDetail("mode must be atomic or partial")
+	}
+
+	dryRun := false
+	if value := c.QueryParam("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return services.ErrInvalidParameter.WithDetail("dry_run must be true or false")
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxCatImportBytes+1))
+	if err != nil {
+		return services.ErrInvalidBody.WithDetail(err.Error())
+	}
+	if len(body) > maxCatImportBytes {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("the body must not exceed %d bytes", maxCatImportBytes))
+	}
+
+	var rows []services.CatImportRow
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case "text/csv", "application/csv":
+		rows, err = services.ParseCatImportCSV(body)
+	case "", echo.MIMEApplicationJSON:
+		rows, err = services.ParseCatImportJSON(body)
+	default:
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "send text/csv or application/json")
+	}
+	if err != nil {
+		return services.ErrInvalidBody.WithDetail(err.Error())
+	}
+	if len(rows) == 0 {
+		return services.ErrInvalidBody.WithDetail("the import contains no cats")
+	}
+	if len(rows) > maxCatImportRows {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("at most %d cats can be imported at once", maxCatImportRows))
+	}
+
+	report, err := h.catService.ImportCats(c.Request().Context(), rows, services.CatImportOptions{Atomic: mode == "atomic", DryRun: dryRun})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dryRun:
+		return c.JSON(http.StatusOK, report)
+	case report.Created == 0:
+		return c.JSON(http.StatusUnprocessableEntity, report)
+	default:
+		return c.JSON(http.StatusCreated, report)
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"spy-cat-agency/internal/domain/apperrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+var errInvalidIfMatch = apperrors.Invalid("invalid_if_match", "If-Match must be an ETag returned by this API")
+
+// setETag exposes the resource version so clients can send it back in If-Match.
+func setETag(c echo.Context, version int32) {
+	c.Response().Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// ifMatchVersion reads the version a client expects from the If-Match header.
+// It returns nil when the header is absent or is the "*" wildcard.
+func ifMatchVersion(c echo.Context) (*int32, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	tag = strings.Trim(tag, `"`)
+
+	version, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil || version <= 0 {
+		return nil, errInvalidIfMatch
+	}
+
+	v := int32(version)
+	return &v, nil
+}
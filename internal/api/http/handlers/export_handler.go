@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/application/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	exportFormatCSV    = "text/csv"
+	exportFormatNDJSON = "application/x-ndjson"
+
+	// exportFlushEvery is how many rows are buffered before they are pushed
+	// to the client.
+	exportFlushEvery = 500
+)
+
+type ExportHandler struct {
+	exportService     services.ExportService
+	validationService *services.ValidationService
+}
+
+func NewExportHandler(exportService services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService:     exportService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// ExportCats streams every spy cat
+// @Summary Export spy cats
+// @Description Stream all spy cats matching the cat list filters as CSV or NDJSON, chosen by the Accept header (CSV by default). Pagination parameters are ignored.
+// @Tags export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param breed query string false "Filter by breed (case-insensitive)"
+// @Param name query string false "Filter by name substring (case-insensitive)"
+// @Param min_experience query int false "Minimum years of experience"
+// @Param max_experience query int false "Maximum years of experience"
+// @Param min_salary query number false "Minimum salary"
+// @Param max_salary query number false "Maximum salary"
+// @Param available query bool false "Only free (true) or busy (false) cats"
+// @Param sort query string false "Sort field" Enums(id, name, salary, years_of_experience)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {array} dto.CatExportRecord
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 406 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/export/cats [get]
+func (h *ExportHandler) ExportCats(c echo.Context) error {
+	filter, err := catFilterFromQuery(c)
+	if err != nil {
+		return invalidParameter(err)
+	}
+
+	return h.stream(c, "cats", dto.CatExportColumns, func(w *exportWriter) error {
+		return h.exportService.ExportCats(c.Request().Context(), filter, func(record dto.CatExportRecord) error {
+			return w.Write(record)
+		})
+	})
+}
+
+// ExportMissions streams every mission
+// @Summary Export missions
+// @Description Stream all missions matching the mission list filters as CSV or NDJSON, chosen by the Accept header (CSV by default). Targets are exported separately. Pagination parameters are ignored.
+// @Tags export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param status query string false "Filter by status" Enums(draft, assigned, active, completed, aborted)
+// @Param completed query bool false "Filter by completion"
+// @Param cat_id query int false "Filter by assigned cat"
+// @Param country query string false "Only missions with a target in this country"
+// @Param from query string false "Missions ending on or after this date"
+// @Param to query string false "Missions starting on or before this date"
+// @Param name query string false "Filter by name substring (case-insensitive)"
+// @Param sort query string false "Sort field" Enums(id, name, status, start_date, end_date, created_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {array} dto.MissionExportRecord
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 406 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/export/missions [get]
+func (h *ExportHandler) ExportMissions(c echo.Context) error {
+	filter, err := missionFilterFromQuery(c)
+	if err != nil {
+		return invalidParameter(err)
+	}
+
+	return h.stream(c, "missions", dto.MissionExportColumns, func(w *exportWriter) error {
+		return h.exportService.ExportMissions(c.Request().Context(), filter, func(record dto.MissionExportRecord) error {
+			return w.Write(record)
+		})
+	})
+}
+
+// ExportTargets streams every target
+// @Summary Export targets
+// @Description Stream the targets of all missions matching the mission list filters as CSV or NDJSON, chosen by the Accept header (CSV by default). Rows are ordered by mission and target ID.
+// @Tags export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param status query string false "Filter by mission status" Enums(draft, assigned, active, completed, aborted)
+// @Param completed query bool false "Filter by mission completion"
+// @Param cat_id query int false "Filter by assigned cat"
+// @Param country query string false "Only missions with a target in this country"
+// @Param from query string false "Missions ending on or after this date"
+// @Param to query string false "Missions starting on or before this date"
+// @Param name query string false "Filter by mission name substring (case-insensitive)"
+// @Success 200 {array} dto.TargetExportRecord
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 406 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/export/targets [get]
+func (h *ExportHandler) ExportTargets(c echo.Context) error {
+	filter, err := missionFilterFromQuery(c)
+	if err != nil {
+		return invalidParameter(err)
+	}
+
+	return h.stream(c, "targets", dto.TargetExportColumns, func(w *exportWriter) error {
+		return h.exportService.ExportTargets(c.Request().Context(), filter, func(record dto.TargetExportRecord) error {
+			return w.Write(record)
+		})
+	})
+}
+
+// stream negotiates the format and runs export. Headers are only sent with the
+// first row, so an export that fails before that still gets a problem response; a
+// failure mid-stream ends the response early and is left to the request log.
+func (h *ExportHandler) stream(c echo.Context, name string, columns []string, export func(w *exportWriter) error) error {
+	format, ok := negotiateExportFormat(c.Request().Header.Get(echo.HeaderAccept))
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotAcceptable, fmt.Sprintf("accept %s or %s", exportFormatCSV, exportFormatNDJSON))
+	}
+
+	w := &exportWriter{c: c, format: format, name: name, columns: columns}
+	if err := export(w); err != nil {
+		if !w.started {
+			return err
+		}
+		return fmt.Errorf("export of %s aborted after %d rows: %w", name, w.rows, err)
+	}
+	return w.Close()
+}
+
+// negotiateExportFormat picks the first export format the Accept header
+// allows, in the client's order; wildcards and no header mean CSV.
+func negotiateExportFormat(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return exportFormatCSV, true
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case exportFormatCSV, "*/*", "text/*":
+			return exportFormatCSV, true
+		case exportFormatNDJSON, "application/ndjson", "application/jsonl":
+			return exportFormatNDJSON, true
+		}
+	}
+	return "", false
+}
+
+type csvRecord interface {
+	CSVRecord() []string
+}
+
+// exportWriter writes rows to the response as they come, flushing every
+// exportFlushEvery rows so memory stays flat however large the export is.
+type exportWriter struct {
+	c       echo.Context
+	format  string
+	name    string
+	columns []string
+	started bool
+	rows    int
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func (w *exportWriter) start() error {
+	w.started = true
+
+	res := w.c.Response()
+	extension := "csv"
+	if w.format == exportFormatNDJSON {
+		extension = "ndjson"
+	}
+	res.Header().Set(echo.HeaderContentType, w.format+"; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, w.name, extension))
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusOK)
+
+	if w.format == exportFormatNDJSON {
+		w.json = json.NewEncoder(res)
+		return nil
+	}
+	w.csv = csv.NewWriter(res)
+	return w.csv.Write(w.columns)
+}
+
+func (w *exportWriter) Write(record csvRecord) error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if w.csv != nil {
+		err = w.csv.Write(record.CSVRecord())
+	} else {
+		err = w.json.Encode(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%exportFlushEvery == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close finishes the export; an empty export still gets its CSV header.
+func (w *exportWriter) Close() error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+func (w *exportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Response().Flush()
+	return nil
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,14 +16,14 @@ import (
 )
 
 type CatHandler struct {
-	catRepo           interfaces.CatRepository
+	catService        services.CatService
 	breedService      *external.BreedService
 	validationService *services.ValidationService
 }
 
-func NewCatHandler(catRepo interfaces.CatRepository) *CatHandler {
+func NewCatHandler(catService services.CatService) *CatHandler {
 	return &CatHandler{
-		catRepo:           catRepo,
+		catService:        catService,
 		breedService:      external.NewBreedService(),
 		validationService: services.NewValidationService(),
 	}
@@ -36,51 +37,30 @@ func NewCatHandler(catRepo interfaces.CatRepository) *CatHandler {
 // @Produce json
 // @Param cat body dto.CreateCatRequest true "Cat creation request"
 // @Success 201 {object} dto.CatResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/cats [post]
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats [post]
 func (h *CatHandler) CreateCat(c echo.Context) error {
 	var req dto.CreateCatRequest
 
 	if err := h.validationService.ValidateJSONBinding(c, &req, "cat"); err != nil {
-		return c.JSON(http.StatusBadRequest, h.validationService.CreateErrorResponse(
-			"Invalid request body format",
-			"Please check your JSON format and field types",
-		))
+		return err
 	}
 
 	if err := h.validationService.ValidateEchoStruct(c, &req, "cat"); err != nil {
-		return c.JSON(http.StatusBadRequest, h.validationService.CreateErrorResponse(
-			"Validation failed",
-			"Name, breed, experience and salary are required",
-		))
-	}
-
-	isValidBreed, err := h.breedService.ValidateBreed(req.Breed)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, h.validationService.CreateErrorResponse(
-			"Failed to validate breed",
-			"Unable to connect to breed validation service",
-		))
-	}
-	if !isValidBreed {
-		return c.JSON(http.StatusBadRequest, h.validationService.CreateErrorResponse(
-			"Invalid breed",
-			"Please use a valid cat breed from TheCatAPI. Available breeds can be fetched from /api/v1/breeds endpoint",
-		))
+		return err
 	}
 
 	spyCat := entities.NewSpyCat(req.Name, req.Breed, req.YearsOfExperience, req.Salary)
 
-	created, err := h.catRepo.Create(c.Request().Context(), spyCat)
+	created, err := h.catService.CreateCat(c.Request().Context(), spyCat)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to create cat",
-			"details": "Database error occurred while creating the spy cat",
-		})
+		return err
 	}
 
 	response := h.toResponseDTO(created)
+	setETag(c, created.Version)
 	return c.JSON(http.StatusCreated, response)
 }
 
@@ -92,67 +72,163 @@ func (h *CatHandler) CreateCat(c echo.Context) error {
 // @Produce json
 // @Param id path int true "Cat ID"
 // @Success 200 {object} dto.CatResponse
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/cats/{id} [get]
 func (h *CatHandler) GetCat(c echo.Context) error {
 	id, err := h.validationService.ValidateCatID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, h.validationService.CreateErrorResponse("Invalid cat ID", err.Error()))
+		return err
 	}
 
-	spyCat, err := h.catRepo.GetByID(c.Request().Context(), id)
+	spyCat, err := h.catService.GetCat(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, h.validationService.CreateErrorResponse("Cat not found", ""))
+		return err
 	}
 
 	response := h.toResponseDTO(spyCat)
+	setETag(c, spyCat.Version)
 	return c.JSON(http.StatusOK, response)
 }
 
 // ListCats retrieves cats with pagination
 // @Summary List spy cats
-// @Description Get a paginated list of spy cats
+// @Description Get a filtered, paginated list of spy cats with the total number of matches
 // @Tags cats
 // @Accept json
 // @Produce json
+// @Param breed query string false "Breed (case-insensitive)"
+// @Param min_experience query int false "Minimum years of experience"
+// @Param max_experience query int false "Maximum years of experience"
+// @Param min_salary query number false "Minimum salary"
+// @Param max_salary query number false "Maximum salary"
+// @Param available query bool false "Only free (true) or busy (false) cats"
+// @Param name query string false "Case-insensitive substring of the cat name"
+// @Param sort query string false "Sort field" Enums(id, name, salary, years_of_experience) default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param include_breeds query bool false "Include the valid breed names in the response" default(false)
 // @Param limit query int false "Limit" default(10)
 // @Param offset query int false "Offset" default(0)
+// @Param cursor query string false "next_cursor of the previous page; replaces offset and needs the same sort and order"
 // @Success 200 {object} dto.CatListResponse
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/cats [get]
 func (h *CatHandler) ListCats(c echo.Context) error {
-	limit, offset, err := h.validationService.ValidatePaginationParams(c)
+	filter, err := catFilterFromQuery(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, h.validationService.CreateErrorResponse("Invalid pagination parameters", err.Error()))
+		return invalidParameter(err)
 	}
 
-	spyCats, err := h.catRepo.List(c.Request().Context(), limit, offset)
+	filter.Limit, filter.Offset, err = h.validationService.ValidatePaginationParams(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, h.validationService.CreateErrorResponse("Failed to list cats", ""))
+		return err
 	}
 
-	breeds, err := h.breedService.GetBreedNames()
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, h.validationService.CreateErrorResponse("Failed to fetch breeds", ""))
+	includeBreeds := false
+	if value := c.QueryParam("include_breeds"); value != "" {
+		if includeBreeds, err = strconv.ParseBool(value); err != nil {
+			return services.ErrInvalidParameter.WithDetail("include_breeds must be true or false")
+		}
 	}
 
-	catResponses := make([]dto.CatResponse, len(spyCats))
-	for i, spyCat := range spyCats {
-		catResponses[i] = *h.toResponseDTO(spyCat)
+	response, err := h.catService.ListCats(c.Request().Context(), filter)
+	if err != nil {
+		return err
 	}
 
-	response := dto.CatListResponse{
-		Cats:   catResponses,
-		Breeds: breeds,
-		Total:  int64(len(catResponses)), // simplified - in real app would be actual count
-		Limit:  limit,
-		Offset: offset,
+	if includeBreeds {
+		breeds, err := h.breedService.GetBreedNames()
+		if err != nil {
+			return err
+		}
+		response.Breeds = breeds
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+func catFilterFromQuery(c echo.Context) (interfaces.CatFilter, error) {
+	var filter interfaces.CatFilter
+
+	if value := strings.TrimSpace(c.QueryParam("breed")); value != "" {
+		filter.Breed = &value
+	}
+
+	if value := strings.TrimSpace(c.QueryParam("name")); value != "" {
+		filter.Name = &value
+	}
+
+	for param, target := range map[string]**int32{
+		"min_experience": &filter.MinExperience,
+		"max_experience": &filter.MaxExperience,
+	} {
+		if value := c.QueryParam(param); value != "" {
+			years, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || years < 0 {
+				return filter, fmt.Errorf("%s must be a non-negative integer", param)
+			}
+			parsed := int32(years)
+			*target = &parsed
+		}
+	}
+
+	for param, target := range map[string]**float64{
+		"min_salary": &filter.MinSalary,
+		"max_salary": &filter.MaxSalary,
+	} {
+		if value := c.QueryParam(param); value != "" {
+			salary, err := strconv.ParseFloat(value, 64)
+			if err != nil || salary < 0 {
+				return filter, fmt.Errorf("%s must be a non-negative number", param)
+			}
+			*target = &salary
+		}
+	}
+
+	if filter.MinExperience != nil && filter.MaxExperience != nil && *filter.MaxExperience < *filter.MinExperience {
+		return filter, fmt.Errorf("max_experience must not be below min_experience")
+	}
+	if filter.MinSalary != nil && filter.MaxSalary != nil && *filter.MaxSalary < *filter.MinSalary {
+		return filter, fmt.Errorf("max_salary must not be below min_salary")
+	}
+
+	if value := c.QueryParam("available"); value != "" {
+		available, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("available must be true or false")
+		}
+		filter.Available = &available
+	}
+
+	if value := c.QueryParam("sort"); value != "" {
+		if !interfaces.IsCatSortField(value) {
+			return filter, fmt.Errorf("sort must be one of: %s", strings.Join(interfaces.CatSortFields, ", "))
+		}
+		filter.SortBy = value
+	}
+
+	switch strings.ToLower(c.QueryParam("order")) {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		return filter, fmt.Errorf("order must be asc or desc")
+	}
+
+	cursor, err := cursorFromQuery(c, filter.SortBy, filter.SortDesc)
+	if err != nil {
+		return filter, err
+	}
+	filter.After = cursor
+
+	return filter, nil
+}
+
 // UpdateCatSalary updates a cat's salary
 // @Summary Update spy cat salary
 // @Description Update the salary of a spy cat
@@ -161,45 +237,91 @@ func (h *CatHandler) ListCats(c echo.Context) error {
 // @Produce json
 // @Param id path int true "Cat ID"
 // @Param salary body dto.UpdateCatSalaryRequest true "Salary update request"
+// @Param If-Match header string false "ETag of the cat the change is based on"
 // @Success 200 {object} dto.CatResponse
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /api/v1/cats/{id}/salary [put]
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats/{id}/salary [put]
 func (h *CatHandler) UpdateCatSalary(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 32)
+	id, err := h.validationService.ValidateCatID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Invalid cat ID",
-			"details": "Cat ID must be a positive integer",
-		})
+		return err
 	}
 
 	var req dto.UpdateCatSalaryRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Invalid request body format",
-			"details": "Please check your JSON format and field types",
-		})
+		return services.ErrInvalidBody
 	}
 
 	// Validate request body
 	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+		return validationFailed(err)
 	}
 
-	updated, err := h.catRepo.UpdateSalary(c.Request().Context(), int32(id), req.Salary)
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error":   "Cat not found",
-			"details": "No spy cat exists with the provided ID",
-		})
+		return err
+	}
+
+	updated, err := h.catService.UpdateCatSalary(c.Request().Context(), id, req.Salary, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	response := h.toResponseDTO(updated)
+	setETag(c, updated.Version)
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateCatProfile partially updates a cat's profile
+// @Summary Update spy cat profile
+// @Description Change the name, breed or years of experience of a spy cat. Omitted fields are left unchanged; breeds are validated against TheCatAPI.
+// @Tags cats
+// @Accept json
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Param profile body dto.UpdateCatProfileRequest true "Profile fields to change"
+// @Param If-Match header string false "ETag of the cat the change is based on"
+// @Success 200 {object} dto.CatResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats/{id} [patch]
+func (h *CatHandler) UpdateCatProfile(c echo.Context) error {
+	id, err := h.validationService.ValidateCatID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCatProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	if req.IsEmpty() {
+		return services.ErrValidationFailed.WithDetail("provide at least one of name, breed or years_of_experience")
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.catService.UpdateCatProfile(c.Request().Context(), id, req, expectedVersion)
+	if err != nil {
+		return err
 	}
 
 	response := h.toResponseDTO(updated)
+	setETag(c, updated.Version)
 	return c.JSON(http.StatusOK, response)
 }
 
@@ -210,30 +332,27 @@ func (h *CatHandler) UpdateCatSalary(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "Cat ID"
+// @Param If-Match header string false "ETag of the cat the deletion is based on"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Router /api/v1/cats/{id} [delete]
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/cats/{id} [delete]
 func (h *CatHandler) DeleteCat(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 32)
+	id, err := h.validationService.ValidateCatID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid cat ID"})
+		return err
 	}
 
-	err = h.catRepo.Delete(c.Request().Context(), int32(id))
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		if strings.Contains(err.Error(), "cannot delete cat: cat is currently assigned to mission") {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error":   "Cannot delete cat",
-				"details": "This spy cat is currently assigned to a mission. Please unassign the cat from the mission before deletion.",
-			})
-		}
-		if strings.Contains(err.Error(), "failed to find cat") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": "Cat not found"})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete cat"})
+		return err
+	}
+
+	if err := h.catService.DeleteCat(c.Request().Context(), id, expectedVersion); err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusNoContent, nil)
@@ -246,26 +365,17 @@ func (h *CatHandler) DeleteCat(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string][]string
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/cats/breeds [get]
 func (h *CatHandler) GetBreeds(c echo.Context) error {
 	breeds, err := h.breedService.GetBreedNames()
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch cat breeds"})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string][]string{"breeds": breeds})
 }
 
 func (h *CatHandler) toResponseDTO(spyCat *entities.SpyCat) *dto.CatResponse {
-	return &dto.CatResponse{
-		ID:                spyCat.ID,
-		Name:              spyCat.Name,
-		YearsOfExperience: spyCat.YearsOfExperience,
-		Breed:             spyCat.Breed,
-		Salary:            spyCat.Salary,
-		MissionID:         spyCat.MissionID,
-		CreatedAt:         spyCat.CreatedAt,
-		UpdatedAt:         spyCat.UpdatedAt,
-	}
+	return dto.CatFromModel(spyCat)
 }
@@ -1,22 +1,29 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"spy-cat-agency/internal/application/dto"
 	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
 
 	"github.com/labstack/echo/v4"
 )
 
 type MissionHandler struct {
-	missionService services.MissionService
+	missionService    services.MissionService
+	validationService *services.ValidationService
 }
 
 func NewMissionHandler(missionService services.MissionService) *MissionHandler {
 	return &MissionHandler{
-		missionService: missionService,
+		missionService:    missionService,
+		validationService: services.NewValidationService(),
 	}
 }
 
@@ -28,56 +35,166 @@ func NewMissionHandler(missionService services.MissionService) *MissionHandler {
 // @Produce json
 // @Param mission body dto.CreateMissionRequest true "Mission data"
 // @Success 201 {object} dto.MissionResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions [post]
 func (h *MissionHandler) CreateMission(c echo.Context) error {
 	var req dto.CreateMissionRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid request body format",
-			"details": "Please check your JSON format and field types",
-		})
+		return services.ErrInvalidBody
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+		return validationFailed(err)
 	}
 
-	mission, err := h.missionService.CreateMission(req)
+	mission, err := h.missionService.CreateMission(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Failed to create mission",
-			"details": err.Error(),
-		})
+		return err
 	}
 
+	setETag(c, mission.Version)
 	return c.JSON(http.StatusCreated, mission)
 }
 
-// ListMissions returns all missions
-// @Summary List all missions
-// @Description Get all spy missions
+// ListMissions returns a page of missions
+// @Summary List missions
+// @Description Get a page of spy missions, filtered by lifecycle status, completion, assigned cat, target country, planned dates or name
 // @Tags missions
 // @Produce json
-// @Success 200 {array} dto.MissionResponse
-// @Failure 500 {object} map[string]interface{}
+// @Param status query string false "Mission status" Enums(draft, assigned, active, completed, aborted)
+// @Param completed query bool false "Only completed (true) or unfinished (false) missions"
+// @Param cat_id query int false "Assigned cat ID"
+// @Param country query string false "Country of at least one target"
+// @Param from query string false "Missions planned to end on or after this date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "Missions planned to start on or before this date (YYYY-MM-DD or RFC3339)"
+// @Param name query string false "Case-insensitive substring of the mission name"
+// @Param sort query string false "Sort field" Enums(id, name, status, start_date, end_date, created_at) default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Param cursor query string false "next_cursor of the previous page; replaces offset and needs the same sort and order"
+// @Success 200 {object} dto.MissionListResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions [get]
 func (h *MissionHandler) ListMissions(c echo.Context) error {
-	missions, err := h.missionService.ListMissions()
+	filter, err := missionFilterFromQuery(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to fetch missions",
-			"details": err.Error(),
-		})
+		return invalidParameter(err)
+	}
+
+	filter.Limit, filter.Offset, err = h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	missions, err := h.missionService.ListMissions(c.Request().Context(), filter)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, missions)
 }
 
+func missionFilterFromQuery(c echo.Context) (interfaces.MissionFilter, error) {
+	var filter interfaces.MissionFilter
+
+	if value := c.QueryParam("status"); value != "" {
+		status, err := entities.ParseMissionStatus(value)
+		if err != nil {
+			return filter, fmt.Errorf("status must be one of: draft, assigned, active, completed, aborted")
+		}
+		filter.Status = &status
+	}
+
+	if value := c.QueryParam("completed"); value != "" {
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("completed must be true or false")
+		}
+		filter.IsCompleted = &completed
+	}
+
+	if value := c.QueryParam("cat_id"); value != "" {
+		catID, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || catID <= 0 {
+			return filter, fmt.Errorf("cat_id must be a positive integer")
+		}
+		id := int32(catID)
+		filter.CatID = &id
+	}
+
+	if value := strings.TrimSpace(c.QueryParam("country")); value != "" {
+		filter.Country = &value
+	}
+
+	if value := strings.TrimSpace(c.QueryParam("name")); value != "" {
+		filter.Name = &value
+	}
+
+	if value := c.QueryParam("from"); value != "" {
+		from, _, err := parseQueryDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("from: %w", err)
+		}
+		filter.From = &from
+	}
+
+	if value := c.QueryParam("to"); value != "" {
+		to, dateOnly, err := parseQueryDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("to: %w", err)
+		}
+		if dateOnly {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return filter, fmt.Errorf("to must not be before from")
+	}
+
+	if value := c.QueryParam("sort"); value != "" {
+		if !interfaces.IsMissionSortField(value) {
+			return filter, fmt.Errorf("sort must be one of: %s", strings.Join(interfaces.MissionSortFields, ", "))
+		}
+		filter.SortBy = value
+	}
+
+	switch strings.ToLower(c.QueryParam("order")) {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		return filter, fmt.Errorf("order must be asc or desc")
+	}
+
+	cursor, err := cursorFromQuery(c, filter.SortBy, filter.SortDesc)
+	if err != nil {
+		return filter, err
+	}
+	filter.After = cursor
+
+	return filter, nil
+}
+
+// parseQueryDate accepts a plain date or an RFC3339 timestamp and reports
+// which one it got.
+func parseQueryDate(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("must be a date (YYYY-MM-DD) or an RFC3339 timestamp")
+	}
+	return timestamp, false, nil
+}
+
 // GetMission returns a specific mission
 // @Summary Get mission by ID
 // @Description Get a specific spy mission by its ID
@@ -85,31 +202,72 @@ func (h *MissionHandler) ListMissions(c echo.Context) error {
 // @Produce json
 // @Param id path int true "Mission ID"
 // @Success 200 {object} dto.MissionResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions/{id} [get]
 func (h *MissionHandler) GetMission(c echo.Context) error {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	id, err := h.validationService.ValidateMissionID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error": "Invalid mission ID",
-		})
+		return err
 	}
 
-	mission, err := h.missionService.GetMission(int32(id))
+	mission, err := h.missionService.GetMission(c.Request().Context(), id)
 	if err != nil {
-		if err.Error() == "failed to get mission: record not found" {
-			return c.JSON(http.StatusNotFound, map[string]interface{}{
-				"error": "Mission not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to fetch mission",
-			"details": err.Error(),
-		})
+		return err
+	}
+
+	setETag(c, mission.Version)
+	return c.JSON(http.StatusOK, mission)
+}
+
+// UpdateMission edits a mission's details
+// @Summary Update mission details
+// @Description Change the name, description or planned dates of a mission. Omitted fields are left unchanged. Completed and aborted missions cannot be edited.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param mission body dto.UpdateMissionRequest true "Fields to change"
+// @Param If-Match header string false "ETag of the mission the change is based on"
+// @Success 200 {object} dto.MissionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/{id} [patch]
+func (h *MissionHandler) UpdateMission(c echo.Context) error {
+	id, err := h.validationService.ValidateMissionID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateMissionRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	if req.IsEmpty() {
+		return services.ErrValidationFailed.WithDetail("provide at least one of name, description, start_date or end_date")
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
+	mission, err := h.missionService.UpdateMission(c.Request().Context(), id, req, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, mission.Version)
 	return c.JSON(http.StatusOK, mission)
 }
 
@@ -118,29 +276,27 @@ func (h *MissionHandler) GetMission(c echo.Context) error {
 // @Description Delete a spy mission by its ID
 // @Tags missions
 // @Param id path int true "Mission ID"
+// @Param If-Match header string false "ETag of the mission the deletion is based on"
 // @Success 204
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions/{id} [delete]
 func (h *MissionHandler) DeleteMission(c echo.Context) error {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	id, err := h.validationService.ValidateMissionID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error": "Invalid mission ID",
-		})
+		return err
 	}
 
-	if err := h.missionService.DeleteMission(int32(id)); err != nil {
-		if err.Error() == "mission with id "+strconv.Itoa(int(id))+" not found" {
-			return c.JSON(http.StatusNotFound, map[string]interface{}{
-				"error": "Mission not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to delete mission",
-			"details": err.Error(),
-		})
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.missionService.DeleteMission(c.Request().Context(), id, expectedVersion); err != nil {
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -155,42 +311,111 @@ func (h *MissionHandler) DeleteMission(c echo.Context) error {
 // @Param id path int true "Mission ID"
 // @Param cat_id body object{cat_id:int} true "Cat assignment data"
 // @Success 200 {object} dto.MissionResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /missions/{id}/assign [post]
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/{id}/assign [post]
 func (h *MissionHandler) AssignCatToMission(c echo.Context) error {
-	missionID, err := strconv.Atoi(c.Param("id"))
+	missionID, err := h.validationService.ValidateMissionID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid mission ID",
-			"details": "Mission ID must be a positive integer",
-		})
+		return err
 	}
 
 	var req dto.AssignCatRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid request body format",
-			"details": "Please check your JSON format and field types",
-		})
+		return services.ErrInvalidBody
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+		return validationFailed(err)
 	}
 
-	mission, err := h.missionService.AssignCatToMission(int32(missionID), req.CatID)
+	mission, err := h.missionService.AssignCatToMission(c.Request().Context(), missionID, req.CatID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to assign cat to mission",
-			"details": err.Error(),
-		})
+		return err
 	}
 
+	setETag(c, mission.Version)
+	return c.JSON(http.StatusOK, mission)
+}
+
+// AbortMission calls off a mission and releases its cat
+// @Summary Abort a mission
+// @Description Abort a mission, freezing all non-completed targets and releasing the assigned cat
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param request body dto.AbortMissionRequest true "Abort reason"
+// @Success 200 {object} dto.MissionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/{id}/abort [post]
+func (h *MissionHandler) AbortMission(c echo.Context) error {
+	missionID, err := h.validationService.ValidateMissionID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AbortMissionRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	mission, err := h.missionService.AbortMission(c.Request().Context(), missionID, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, mission.Version)
+	return c.JSON(http.StatusOK, mission)
+}
+
+// ReassignMission hands a mission over to another cat
+// @Summary Hand a mission over to another cat
+// @Description Move a staffed mission to a free cat, keeping all target progress and recording the handover
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param request body dto.ReassignCatRequest true "Handover request"
+// @Success 200 {object} dto.MissionResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/{id}/reassign [post]
+func (h *MissionHandler) ReassignMission(c echo.Context) error {
+	missionID, err := h.validationService.ValidateMissionID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReassignCatRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	mission, err := h.missionService.ReassignMission(c.Request().Context(), missionID, req.CatID, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, mission.Version)
 	return c.JSON(http.StatusOK, mission)
 }
 
@@ -200,15 +425,13 @@ func (h *MissionHandler) AssignCatToMission(c echo.Context) error {
 // @Tags cats
 // @Produce json
 // @Success 200 {array} dto.CatResponse
-// @Failure 500 {object} map[string]interface{}
-// @Router /missions/free-cats [get]
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/free-cats [get]
 func (h *MissionHandler) GetFreeCats(c echo.Context) error {
-	cats, err := h.missionService.GetFreeCats()
+	cats, err := h.missionService.GetFreeCats(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to get free cats",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, cats)
@@ -223,36 +446,28 @@ func (h *MissionHandler) GetFreeCats(c echo.Context) error {
 // @Param missionId path int true "Mission ID"
 // @Param request body dto.AddTargetRequest true "Add target request"
 // @Success 201 {object} dto.TargetResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions/{missionId}/targets [post]
 func (h *MissionHandler) AddTargetToMission(c echo.Context) error {
-	missionIDStr := c.Param("id")
-	missionID, err := strconv.ParseInt(missionIDStr, 10, 32)
+	missionID, err := h.validationService.ValidateMissionID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid mission ID",
-			"details": "Mission ID must be a valid integer",
-		})
+		return err
 	}
 
 	var req dto.AddTargetRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		return services.ErrInvalidBody
 	}
 
-	target, err := h.missionService.AddTargetToMission(int32(missionID), req)
+	target, err := h.missionService.AddTargetToMission(c.Request().Context(), missionID, req)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Failed to add target",
-			"details": err.Error(),
-		})
+		return err
 	}
 
+	setETag(c, target.Version)
 	return c.JSON(http.StatusCreated, target)
 }
 
@@ -262,41 +477,93 @@ func (h *MissionHandler) AddTargetToMission(c echo.Context) error {
 // @Tags missions
 // @Param missionId path int true "Mission ID"
 // @Param targetId path int true "Target ID"
+// @Param If-Match header string false "ETag of the target the deletion is based on"
 // @Success 204 "Target deleted successfully"
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/agency/missions/{missionId}/targets/{targetId} [delete]
 func (h *MissionHandler) DeleteTargetFromMission(c echo.Context) error {
-	missionIDStr := c.Param("id")
-	missionID, err := strconv.ParseInt(missionIDStr, 10, 32)
+	missionID, err := h.validationService.ValidateMissionID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid mission ID",
-			"details": "Mission ID must be a valid integer",
-		})
+		return err
 	}
 
-	targetIDStr := c.Param("targetId")
-	targetID, err := strconv.ParseInt(targetIDStr, 10, 32)
+	targetID, err := h.validationService.ValidateTargetID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid target ID",
-			"details": "Target ID must be a valid integer",
-		})
+		return err
 	}
 
-	err = h.missionService.DeleteTargetFromMission(int32(missionID), int32(targetID))
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Failed to delete target",
-			"details": err.Error(),
-		})
+		return err
+	}
+
+	if err := h.missionService.DeleteTargetFromMission(c.Request().Context(), missionID, targetID, expectedVersion); err != nil {
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// UpdateTarget edits a target of a mission
+// @Summary Update a target
+// @Description Change a target's name, country or notes. Completed and frozen targets, and targets of finished missions, cannot be edited. Target status is reported by the assigned cat.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param targetId path int true "Target ID"
+// @Param request body dto.UpdateTargetRequest true "Fields to change"
+// @Param If-Match header string false "ETag of the target the change is based on"
+// @Success 200 {object} dto.TargetResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/missions/{id}/targets/{targetId} [put]
+func (h *MissionHandler) UpdateTarget(c echo.Context) error {
+	missionID, err := h.validationService.ValidateMissionID(c)
+	if err != nil {
+		return err
+	}
+
+	targetID, err := h.validationService.ValidateTargetID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateTargetRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	if req.IsEmpty() {
+		return services.ErrValidationFailed.WithDetail("provide at least one of name, country or notes")
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	target, err := h.missionService.UpdateTarget(c.Request().Context(), missionID, targetID, req, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, target.Version)
+	return c.JSON(http.StatusOK, target)
+}
+
 // GetCatMission gets the current mission for a specific cat
 // @Summary Get cat's current mission
 // @Description Get the current mission assigned to a specific cat with all targets
@@ -305,25 +572,19 @@ func (h *MissionHandler) DeleteTargetFromMission(c echo.Context) error {
 // @Param catId path int true "Cat ID"
 // @Success 200 {object} dto.MissionResponse
 // @Success 204 "Cat has no assigned mission"
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/spy-cats/{catId}/mission [get]
 func (h *MissionHandler) GetCatMission(c echo.Context) error {
-	catIDStr := c.Param("catId")
-	catID, err := strconv.ParseInt(catIDStr, 10, 32)
+	catID, err := h.validationService.ValidateID(c.Param("catId"), "cat ID")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid cat ID",
-			"details": "Cat ID must be a valid integer",
-		})
+		return err
 	}
 
-	mission, err := h.missionService.GetCatMission(int32(catID))
+	mission, err := h.missionService.GetCatMission(c.Request().Context(), catID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "Failed to get cat mission",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	if mission == nil {
@@ -333,6 +594,47 @@ func (h *MissionHandler) GetCatMission(c echo.Context) error {
 	return c.JSON(http.StatusOK, mission)
 }
 
+// GetCatMissionHistory lists the missions a cat has worked on
+// @Summary Get a cat's mission history
+// @Description Missions the cat was assigned to, newest assignment first, including completed, aborted and handed-over ones
+// @Tags cats
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Param cursor query string false "next_cursor of the previous page; replaces offset"
+// @Success 200 {object} dto.MissionAssignmentListResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/cats/{id}/missions [get]
+func (h *MissionHandler) GetCatMissionHistory(c echo.Context) error {
+	catID, err := h.validationService.ValidateCatID(c)
+	if err != nil {
+		return err
+	}
+
+	filter := interfaces.MissionAssignmentFilter{CatID: catID}
+	filter.Limit, filter.Offset, err = h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	filter.After, err = cursorFromQuery(c, "assigned_at", true)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.missionService.ListCatMissionHistory(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
 // UpdateTargetStatus updates the status of a target (spy cat functionality)
 // @Summary Update target status
 // @Description Update the status of a target (only by assigned cat)
@@ -342,48 +644,44 @@ func (h *MissionHandler) GetCatMission(c echo.Context) error {
 // @Param catId path int true "Cat ID"
 // @Param targetId path int true "Target ID"
 // @Param request body object{status:string} true "Status update request"
+// @Param If-Match header string false "ETag of the target the change is based on"
 // @Success 200 {object} dto.TargetResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/spy-cats/{catId}/mission/targets/{targetId}/status [put]
 func (h *MissionHandler) UpdateTargetStatus(c echo.Context) error {
-	catIDStr := c.Param("catId")
-	catID, err := strconv.ParseInt(catIDStr, 10, 32)
+	catID, err := h.validationService.ValidateID(c.Param("catId"), "cat ID")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid cat ID",
-			"details": "Cat ID must be a valid integer",
-		})
+		return err
 	}
 
-	targetIDStr := c.Param("targetId")
-	targetID, err := strconv.ParseInt(targetIDStr, 10, 32)
+	targetID, err := h.validationService.ValidateTargetID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid target ID",
-			"details": "Target ID must be a valid integer",
-		})
+		return err
 	}
 
 	var req struct {
 		Status string `json:"status" validate:"required,oneof=init in_progress completed"`
 	}
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		return services.ErrInvalidBody
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
-	target, err := h.missionService.UpdateTargetStatus(int32(catID), int32(targetID), req.Status)
+	target, err := h.missionService.UpdateTargetStatus(c.Request().Context(), catID, targetID, req.Status, expectedVersion)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Failed to update target status",
-			"details": err.Error(),
-		})
+		return err
 	}
 
+	setETag(c, target.Version)
 	return c.JSON(http.StatusOK, target)
 }
 
@@ -396,47 +694,42 @@ func (h *MissionHandler) UpdateTargetStatus(c echo.Context) error {
 // @Param catId path int true "Cat ID"
 // @Param targetId path int true "Target ID"
 // @Param request body object{notes:string} true "Notes update request"
+// @Param If-Match header string false "ETag of the target the change is based on"
 // @Success 200 {object} dto.TargetResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 412 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
 // @Router /api/v1/spy-cats/{catId}/mission/targets/{targetId}/notes [put]
 func (h *MissionHandler) UpdateTargetNotes(c echo.Context) error {
-	catIDStr := c.Param("catId")
-	catID, err := strconv.ParseInt(catIDStr, 10, 32)
+	catID, err := h.validationService.ValidateID(c.Param("catId"), "cat ID")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid cat ID",
-			"details": "Cat ID must be a valid integer",
-		})
+		return err
 	}
 
-	targetIDStr := c.Param("targetId")
-	targetID, err := strconv.ParseInt(targetIDStr, 10, 32)
+	targetID, err := h.validationService.ValidateTargetID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid target ID",
-			"details": "Target ID must be a valid integer",
-		})
+		return err
 	}
 
 	var req struct {
 		Notes string `json:"notes"`
 	}
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		return services.ErrInvalidBody
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
-	target, err := h.missionService.UpdateTargetNotes(int32(catID), int32(targetID), req.Notes)
+	target, err := h.missionService.UpdateTargetNotes(c.Request().Context(), catID, targetID, req.Notes, expectedVersion)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error":   "Failed to update target notes",
-			"details": err.Error(),
-		})
+		return err
 	}
 
+	setETag(c, target.Version)
 	return c.JSON(http.StatusOK, target)
 }
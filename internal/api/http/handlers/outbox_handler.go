@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OutboxHandler struct {
+	outboxService     services.OutboxService
+	validationService *services.ValidationService
+}
+
+func NewOutboxHandler(outboxService services.OutboxService) *OutboxHandler {
+	return &OutboxHandler{
+		outboxService:     outboxService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// ListOutboxEvents lists recorded events, newest first
+// @Summary List outbox events
+// @Description Inspect events waiting for delivery, delivered, or given up on (dead)
+// @Tags outbox
+// @Produce json
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.OutboxEventResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/outbox [get]
+func (h *OutboxHandler) ListOutboxEvents(c echo.Context) error {
+	limit, offset, err := h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	filter := interfaces.OutboxFilter{Limit: limit, Offset: offset}
+	if statusStr := c.QueryParam("status"); statusStr != "" {
+		status := entities.OutboxStatus(statusStr)
+		if !status.IsValid() {
+			return services.ErrInvalidParameter.WithDetail("status must be one of: pending, delivered, dead")
+		}
+		filter.Status = &status
+	}
+
+	outboxEvents, err := h.outboxService.ListOutboxEvents(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, outboxEvents)
+}
+
+// ReplayOutboxEvent queues an event for delivery again
+// @Summary Replay an outbox event
+// @Description Reset a stuck, dead or delivered event so the dispatcher delivers it again
+// @Tags outbox
+// @Produce json
+// @Param id path int true "Outbox event ID"
+// @Success 200 {object} dto.OutboxEventResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/outbox/{id}/replay [post]
+func (h *OutboxHandler) ReplayOutboxEvent(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return services.ErrInvalidParameter.WithDetail("outbox event ID must be a positive integer")
+	}
+
+	event, err := h.outboxService.ReplayOutboxEvent(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, event)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+
+	"github.com/labstack/echo/v4"
+)
+
+// cursorFromQuery reads the keyset cursor of a listing. A cursor only continues
+// the ordering it was issued for and cannot be combined with offset.
+func cursorFromQuery(c echo.Context, sortBy string, desc bool) (*interfaces.Cursor, error) {
+	value := c.QueryParam("cursor")
+	if value == "" {
+		return nil, nil
+	}
+
+	if c.QueryParam("offset") != "" {
+		return nil, services.ErrInvalidParameter.WithDetail("cursor cannot be combined with offset")
+	}
+
+	cursor, err := interfaces.DecodeCursor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	if !cursor.Matches(sortBy, desc) {
+		return nil, entities.ErrInvalidCursor.WithDetail("cursor was issued for a different sort order")
+	}
+
+	return cursor, nil
+}
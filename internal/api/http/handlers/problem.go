@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/apperrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:spy-cat-agency:problem:"
+)
+
+var kindStatus = map[apperrors.Kind]int{
+	apperrors.KindInvalid:            http.StatusBadRequest,
+	apperrors.KindUnauthenticated:    http.StatusUnauthorized,
+	apperrors.KindForbidden:          http.StatusForbidden,
+	apperrors.KindNotFound:           http.StatusNotFound,
+	apperrors.KindConflict:           http.StatusConflict,
+	apperrors.KindInvalidTransition:  http.StatusConflict,
+	apperrors.KindPreconditionFailed: http.StatusPreconditionFailed,
+	apperrors.KindLimitExceeded:      http.StatusUnprocessableEntity,
+	apperrors.KindRateLimited:        http.StatusTooManyRequests,
+	apperrors.KindUnavailable:        http.StatusServiceUnavailable,
+	apperrors.KindInternal:           http.StatusInternalServerError,
+}
+
+// HTTPErrorHandler renders every error a handler returns as an RFC 7807
+// problem document. Coded errors keep their code; Echo's own errors (unknown
+// routes, oversized bodies, ...) get a code derived from their status, and
+// anything else is an internal error whose text is not exposed.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := problemFromError(err)
+	problem.Instance = c.Request().URL.Path
+	if problem.Status == http.StatusUnauthorized {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer, ApiKey")
+	}
+
+	var respErr error
+	if c.Request().Method == http.MethodHead {
+		respErr = c.NoContent(problem.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+		respErr = c.JSON(problem.Status, problem)
+	}
+	if respErr != nil {
+		c.Logger().Error(respErr)
+	}
+}
+
+func problemFromError(err error) dto.ProblemResponse {
+	if coded, ok := apperrors.As(err); ok {
+		status, known := kindStatus[coded.Kind()]
+		if !known {
+			status = http.StatusInternalServerError
+		}
+		return newProblem(status, coded.Code(), err.Error())
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return newProblem(httpErr.Code, statusCode(httpErr.Code), fmt.Sprint(httpErr.Message))
+	}
+
+	return newProblem(http.StatusInternalServerError, "internal_error", "an unexpected error occurred")
+}
+
+func newProblem(status int, code, detail string) dto.ProblemResponse {
+	return dto.ProblemResponse{
+		Type:   problemTypePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// statusCode turns an HTTP status into a code such as "method_not_allowed".
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "http_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+// invalidParameter reports a malformed path or query parameter. Errors that
+// are already coded, such as a bad cursor, keep their own code.
+func invalidParameter(err error) error {
+	if _, ok := apperrors.As(err); ok {
+		return err
+	}
+	return services.ErrInvalidParameter.WithDetail(err.Error())
+}
+
+// validationFailed reports a request body that did not pass c.Validate.
+func validationFailed(err error) error {
+	return services.ErrValidationFailed.WithDetail(err.Error())
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"spy-cat-agency/internal/application/services"
+	"spy-cat-agency/internal/domain/interfaces"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchHandler struct {
+	searchService     services.SearchService
+	validationService *services.ValidationService
+}
+
+func NewSearchHandler(searchService services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService:     searchService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// Search runs a full-text search over missions and targets
+// @Summary Search missions and targets
+// @Description Full-text search over mission names and descriptions and target names, countries and notes. Supports "quoted phrases", -exclusions and or. Results are ranked; matches in snippets are wrapped in <mark>.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param type query string false "Comma-separated result types to include" Enums(mission, target)
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/search [get]
+func (h *SearchHandler) Search(c echo.Context) error {
+	query := interfaces.SearchQuery{Text: c.QueryParam("q")}
+
+	if value := c.QueryParam("type"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			resultType := interfaces.SearchResultType(strings.ToLower(strings.TrimSpace(name)))
+			if !resultType.IsValid() {
+				return services.ErrInvalidParameter.WithDetail("type must be mission, target or both")
+			}
+			query.Types = append(query.Types, resultType)
+		}
+	}
+
+	var err error
+	query.Limit, query.Offset, err = h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	results, err := h.searchService.Search(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
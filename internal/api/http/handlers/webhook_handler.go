@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/application/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookService    services.WebhookService
+	validationService *services.ValidationService
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService:    webhookService,
+		validationService: services.NewValidationService(),
+	}
+}
+
+// CreateWebhook subscribes a URL to domain events
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to events. Omit events (or pass "*") to receive all of them. The signing secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body dto.CreateWebhookRequest true "Webhook subscription"
+// @Success 201 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var req dto.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// ListWebhooks returns all webhook subscriptions
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.WebhookResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	webhooks, err := h.webhookService.ListWebhooks(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook returns one webhook subscription
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	id, err := h.validationService.ValidateID(c.Param("id"), "webhook ID")
+	if err != nil {
+		return err
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook changes a webhook subscription
+// @Summary Update a webhook subscription
+// @Description Change the URL, event filter, description, or pause it with active=false
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body dto.UpdateWebhookRequest true "Fields to change"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhook(c echo.Context) error {
+	id, err := h.validationService.ValidateID(c.Param("id"), "webhook ID")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return services.ErrInvalidBody
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return validationFailed(err)
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request().Context(), id, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook removes a webhook subscription
+// @Summary Delete a webhook subscription
+// @Tags webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := h.validationService.ValidateID(c.Param("id"), "webhook ID")
+	if err != nil {
+		return err
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook
+// @Summary List webhook deliveries
+// @Description Delivery attempts for a subscription, newest first
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Security BearerAuth
+// @Router /api/v1/agency/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c echo.Context) error {
+	id, err := h.validationService.ValidateID(c.Param("id"), "webhook ID")
+	if err != nil {
+		return err
+	}
+
+	limit, offset, err := h.validationService.ValidatePaginationParams(c)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request().Context(), id, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
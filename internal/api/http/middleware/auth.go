@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Authenticator resolves a credential sent in the Authorization header to the
+// caller it belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*auth.Principal, error)
+}
+
+// Schemes maps Authorization header schemes, such as Bearer and ApiKey, to the
+// authenticator for their credentials. Schemes match case-insensitively.
+type Schemes map[string]Authenticator
+
+func (s Schemes) lookup(scheme string) (Authenticator, bool) {
+	for name, authenticator := range s {
+		if strings.EqualFold(name, scheme) {
+			return authenticator, true
+		}
+	}
+	return nil, false
+}
+
+// Authenticate puts the caller named by the Authorization header on the
+// request context. Requests without the header pass through anonymously and
+// are turned away by Require or RequireCat where it matters; a header with an
+// unknown scheme or a bad credential is always rejected.
+func Authenticate(schemes Schemes) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(c)
+			}
+
+			scheme, credential, _ := strings.Cut(header, " ")
+			credential = strings.TrimSpace(credential)
+			authenticator, ok := schemes.lookup(scheme)
+			if !ok || credential == "" {
+				return entities.ErrInvalidToken
+			}
+
+			ctx := c.Request().Context()
+			principal, err := authenticator.Authenticate(ctx, credential)
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(ctx, principal)))
+			return next(c)
+		}
+	}
+}
+
+// Require declares the permission a route needs. Anonymous callers get 401,
+// callers whose role lacks the permission 403.
+func Require(permission auth.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, _ := auth.PrincipalFrom(c.Request().Context())
+			if err := entities.Authorize(principal, permission); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireCat lets a request through only when the caller is the spy cat whose
+// ID is in the named path parameter.
+func RequireCat(param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := auth.PrincipalFrom(c.Request().Context())
+			if !ok {
+				return entities.ErrAuthRequired
+			}
+
+			catID, err := strconv.ParseInt(c.Param(param), 10, 32)
+			if err != nil || !principal.IsCat(int32(catID)) {
+				return entities.ErrNotThisCat
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+)
+
+type staticAuthenticator struct {
+	principal *auth.Principal
+}
+
+func (a staticAuthenticator) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	return a.principal, nil
+}
+
+func okHandler(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+func TestAuthenticateRejectsBadHeaders(t *testing.T) {
+	handler := Authenticate(Schemes{"Bearer": staticAuthenticator{&auth.Principal{Role: auth.RoleDirector}}})(okHandler)
+
+	for _, header := range []string{"Basic dXNlcjpwYXNz", "Token abc", "Bearer", "Bearer   "} {
+		t.Run(header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAuthorization, header)
+			err := handler(echo.New().NewContext(req, httptest.NewRecorder()))
+			if !errors.Is(err, entities.ErrInvalidToken) {
+				t.Errorf("error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateMatchesSchemesCaseInsensitively(t *testing.T) {
+	principal := &auth.Principal{CredentialID: 1, Role: auth.RoleDirector}
+	var got *auth.Principal
+	handler := Authenticate(Schemes{"Bearer": staticAuthenticator{principal}})(func(c echo.Context) error {
+		got, _ = auth.PrincipalFrom(c.Request().Context())
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "bearer token")
+	if err := handler(echo.New().NewContext(req, httptest.NewRecorder())); err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	if got != principal {
+		t.Errorf("principal = %+v, want %+v", got, principal)
+	}
+}
+
+func TestRequireCat(t *testing.T) {
+	catID := int32(7)
+	cat := &auth.Principal{CredentialID: 3, Role: auth.RoleCat, CatID: &catID}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		param     string
+		want      error
+	}{
+		{name: "same cat", principal: cat, param: "7"},
+		{name: "other cat", principal: cat, param: "8", want: entities.ErrNotThisCat},
+		{name: "bad cat id", principal: cat, param: "seven", want: entities.ErrNotThisCat},
+		{name: "staff", principal: &auth.Principal{CredentialID: 1, Role: auth.RoleDirector}, param: "7", want: entities.ErrNotThisCat},
+		{name: "anonymous", param: "7", want: entities.ErrAuthRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetParamNames("catId")
+			c.SetParamValues(tt.param)
+
+			err := RequireCat("catId")(okHandler)(c)
+			if tt.want == nil && err != nil {
+				t.Errorf("error = %v, want none", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
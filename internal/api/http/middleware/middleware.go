@@ -22,6 +22,9 @@ type LogEntry struct {
 	RemoteIP    string      `json:"remote_ip,omitempty"`
 }
 
+// redactedFields are request body fields that never reach the log.
+var redactedFields = []string{"password", "secret"}
+
 func LoggingMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -34,10 +37,15 @@ func LoggingMiddleware() echo.MiddlewareFunc {
 
 				if len(bodyBytes) > 0 {
 					json.Unmarshal(bodyBytes, &requestBody)
+					redact(requestBody)
 				}
 			}
 
 			err := next(c)
+			if err != nil {
+				// Render the error now so the logged status is the one sent.
+				c.Error(err)
+			}
 
 			latency := time.Since(start)
 
@@ -64,3 +72,15 @@ func LoggingMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+func redact(body interface{}) {
+	fields, ok := body.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, name := range redactedFields {
+		if _, present := fields[name]; present {
+			fields[name] = "[REDACTED]"
+		}
+	}
+}
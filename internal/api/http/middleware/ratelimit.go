@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/domain/apperrors"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/infrastructure/ratelimit"
+
+	"github.com/labstack/echo/v4"
+)
+
+var ErrRateLimited = apperrors.RateLimited("rate_limited", "too many requests")
+
+// RateLimit takes a token from the caller's bucket for the route group and
+// refuses the request with 429 once it is empty. Callers are told apart by API
+// key, then by login, then by IP, so it must run after Authenticate. Every
+// limited response carries RateLimit-* headers; when a route is in several
+// groups, the innermost one sets them.
+func RateLimit(limiter *ratelimit.Limiter, group string) echo.MiddlewareFunc {
+	return rateLimit(limiter, group, clientKey)
+}
+
+// RateLimitByIP limits requests by client IP alone. It runs before
+// Authenticate, so requests with rejected credentials are limited too.
+func RateLimitByIP(limiter *ratelimit.Limiter, group string) echo.MiddlewareFunc {
+	return rateLimit(limiter, group, ipKey)
+}
+
+func rateLimit(limiter *ratelimit.Limiter, group string, key func(echo.Context) (kind, client string)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			kind, client := key(c)
+			decision, limited := limiter.Allow(group, kind, client)
+			if !limited {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit.Burst))
+			header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", decision.Limit.Requests, ceilSeconds(decision.Limit.Per), decision.Limit.Burst))
+
+			if !decision.Allowed {
+				retryAfter := ceilSeconds(decision.RetryAfter)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return ErrRateLimited.WithDetail(fmt.Sprintf("rate limit for %s requests exceeded, retry in %d seconds", group, retryAfter))
+			}
+			return next(c)
+		}
+	}
+}
+
+func clientKey(c echo.Context) (kind, key string) {
+	if principal, ok := auth.PrincipalFrom(c.Request().Context()); ok {
+		if principal.APIKeyID != nil {
+			return "api_key", fmt.Sprintf("api_key:%d", *principal.APIKeyID)
+		}
+		return "principal", fmt.Sprintf("principal:%d", principal.CredentialID)
+	}
+	return ipKey(c)
+}
+
+func ipKey(c echo.Context) (kind, key string) {
+	return "ip", "ip:" + c.RealIP()
+}
+
+// IPExtractor reads the client IP from X-Forwarded-For, trusting only the
+// given proxy networks (CIDRs or single addresses). Without any, the IP of
+// the connection is used and the header is ignored, so clients cannot pick
+// the IP they are rate limited by.
+func IPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		network, err := parseNetwork(strings.TrimSpace(proxy))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+func parseNetwork(value string) (*net.IPNet, error) {
+	if ip := net.ParseIP(value); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("trusted proxy %q must be an IP address or CIDR", value)
+	}
+	return network, nil
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/infrastructure/ratelimit"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestIPExtractor(t *testing.T) {
+	tests := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		want       string
+	}{
+		{name: "no trusted proxies ignores the header", remoteAddr: "10.0.0.1:1234", want: "10.0.0.1"},
+		{name: "trusted proxy address", trusted: []string{"10.0.0.1"}, remoteAddr: "10.0.0.1:1234", want: "203.0.113.9"},
+		{name: "trusted proxy network", trusted: []string{" 10.0.0.0/24 "}, remoteAddr: "10.0.0.7:1234", want: "203.0.113.9"},
+		{name: "other private address", trusted: []string{"10.0.0.1"}, remoteAddr: "192.168.1.5:1234", want: "192.168.1.5"},
+		{name: "loopback is not trusted by default", trusted: []string{"10.0.0.1"}, remoteAddr: "127.0.0.1:1234", want: "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extract, err := IPExtractor(tt.trusted)
+			if err != nil {
+				t.Fatalf("IPExtractor: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.9")
+			if got := extract(req); got != tt.want {
+				t.Errorf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPExtractorRejectsBadProxies(t *testing.T) {
+	for _, proxy := range []string{"proxy.internal", "10.0.0.0/33", ""} {
+		if _, err := IPExtractor([]string{proxy}); err == nil {
+			t.Errorf("proxy %q: want an error", proxy)
+		}
+	}
+}
+
+type rejectingAuthenticator struct{}
+
+func (rejectingAuthenticator) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	return nil, entities.ErrInvalidToken
+}
+
+func TestRateLimitByIPCountsRejectedCredentials(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Limits: map[string]ratelimit.Limit{
+		ratelimit.GroupIP: {Requests: 1, Per: time.Minute, Burst: 2},
+	}})
+	handler := RateLimitByIP(limiter, ratelimit.GroupIP)(
+		Authenticate(Schemes{"Bearer": rejectingAuthenticator{}})(
+			func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+		),
+	)
+
+	e := echo.New()
+	guess := func(remoteAddr string) error {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderAuthorization, "Bearer guess")
+		return handler(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := guess("192.0.2.1:1234"); !errors.Is(err, entities.ErrInvalidToken) {
+			t.Fatalf("guess %d: error = %v, want ErrInvalidToken", i+1, err)
+		}
+	}
+	if err := guess("192.0.2.1:1234"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("third guess: error = %v, want ErrRateLimited", err)
+	}
+	if err := guess("198.51.100.7:1234"); !errors.Is(err, entities.ErrInvalidToken) {
+		t.Errorf("guess from another IP: error = %v, want ErrInvalidToken", err)
+	}
+}
@@ -1,43 +1,98 @@
 package routes
 
 import (
+	"expvar"
+
 	"spy-cat-agency/internal/api/http/handlers"
+	"spy-cat-agency/internal/api/http/middleware"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/infrastructure/ratelimit"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func SetupRoutes(e *echo.Echo, catHandler *handlers.CatHandler, missionHandler *handlers.MissionHandler) {
+// SetupRoutes registers every route together with the permission it needs.
+// Routes without a permission are public. The services check the same
+// permissions again. The whole API shares the default rate limit; logins and
+// routes that call TheCatAPI have stricter ones on top.
+func SetupRoutes(e *echo.Echo, limiter *ratelimit.Limiter, catHandler *handlers.CatHandler, missionHandler *handlers.MissionHandler, outboxHandler *handlers.OutboxHandler, webhookHandler *handlers.WebhookHandler, searchHandler *handlers.SearchHandler, exportHandler *handlers.ExportHandler, authHandler *handlers.AuthHandler, apiKeyHandler *handlers.APIKeyHandler, auditHandler *handlers.AuditHandler) {
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	api := e.Group("/api/v1")
+	limit := func(group string) echo.MiddlewareFunc {
+		return middleware.RateLimit(limiter, group)
+	}
+
+	api := e.Group("/api/v1", limit(ratelimit.GroupDefault))
+
+	can := middleware.Require
+
+	api.POST("/auth/login", authHandler.Login, limit(ratelimit.GroupAuth))
 
-	api.GET("/cats", catHandler.ListCats)
-	api.GET("/cats/:id", catHandler.GetCat)
-	api.GET("/cats/breeds", catHandler.GetBreeds)
+	api.GET("/cats", catHandler.ListCats, can(auth.PermCatsRead))
+	api.GET("/cats/:id", catHandler.GetCat, can(auth.PermCatsRead))
+	api.GET("/cats/breeds", catHandler.GetBreeds, limit(ratelimit.GroupCatAPI))
+	api.GET("/cats/:id/missions", missionHandler.GetCatMissionHistory, can(auth.PermMissionsRead))
 
 	agency := api.Group("/agency")
 
+	agency.POST("/staff", authHandler.CreateStaff, can(auth.PermStaffManage))
+
+	agencyAPIKeys := agency.Group("/api-keys")
+	agencyAPIKeys.POST("", apiKeyHandler.CreateAPIKey, can(auth.PermAPIKeysManage))
+	agencyAPIKeys.GET("", apiKeyHandler.ListAPIKeys, can(auth.PermAPIKeysManage))
+	agencyAPIKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey, can(auth.PermAPIKeysManage))
+
 	agencyCats := agency.Group("/cats")
-	agencyCats.POST("", catHandler.CreateCat)
-	agencyCats.PUT("/:id/salary", catHandler.UpdateCatSalary)
-	agencyCats.DELETE("/:id", catHandler.DeleteCat)
+	agencyCats.POST("", catHandler.CreateCat, can(auth.PermCatsWrite), limit(ratelimit.GroupCatAPI))
+	agencyCats.POST("/import", catHandler.ImportCats, can(auth.PermCatsWrite), limit(ratelimit.GroupCatAPI))
+	agencyCats.PUT("/:id/salary", catHandler.UpdateCatSalary, can(auth.PermCatsSalary))
+	agencyCats.PATCH("/:id", catHandler.UpdateCatProfile, can(auth.PermCatsWrite), limit(ratelimit.GroupCatAPI))
+	agencyCats.DELETE("/:id", catHandler.DeleteCat, can(auth.PermCatsDelete))
+	agencyCats.PUT("/:id/credentials", authHandler.SetCatCredentials, can(auth.PermStaffManage))
 
 	agencyMissions := agency.Group("/missions")
-	agencyMissions.POST("", missionHandler.CreateMission)
-	agencyMissions.GET("", missionHandler.ListMissions)
-	agencyMissions.GET("/:id", missionHandler.GetMission)
-	agencyMissions.DELETE("/:id", missionHandler.DeleteMission)
-	agencyMissions.POST("/:id/assign", missionHandler.AssignCatToMission)
-	agencyMissions.GET("/free-cats", missionHandler.GetFreeCats)
-
-	agencyMissions.POST("/:id/targets", missionHandler.AddTargetToMission)
-	agencyMissions.DELETE("/:id/targets/:targetId", missionHandler.DeleteTargetFromMission)
-
-	spyCats := api.Group("/spy-cats/:catId")
-	spyCats.GET("/mission", missionHandler.GetCatMission)
-	spyCats.PUT("/mission/targets/:targetId/status", missionHandler.UpdateTargetStatus)
-	spyCats.PUT("/mission/targets/:targetId/notes", missionHandler.UpdateTargetNotes)
+	agencyMissions.POST("", missionHandler.CreateMission, can(auth.PermMissionsWrite))
+	agencyMissions.GET("", missionHandler.ListMissions, can(auth.PermMissionsRead))
+	agencyMissions.GET("/:id", missionHandler.GetMission, can(auth.PermMissionsRead))
+	agencyMissions.PATCH("/:id", missionHandler.UpdateMission, can(auth.PermMissionsWrite))
+	agencyMissions.DELETE("/:id", missionHandler.DeleteMission, can(auth.PermMissionsDelete))
+	agencyMissions.POST("/:id/assign", missionHandler.AssignCatToMission, can(auth.PermMissionsAssign))
+	agencyMissions.POST("/:id/abort", missionHandler.AbortMission, can(auth.PermMissionsWrite))
+	agencyMissions.POST("/:id/reassign", missionHandler.ReassignMission, can(auth.PermMissionsAssign))
+	agencyMissions.GET("/free-cats", missionHandler.GetFreeCats, can(auth.PermCatsRead))
+
+	agencyMissions.POST("/:id/targets", missionHandler.AddTargetToMission, can(auth.PermMissionsWrite))
+	agencyMissions.PUT("/:id/targets/:targetId", missionHandler.UpdateTarget, can(auth.PermMissionsWrite))
+	agencyMissions.DELETE("/:id/targets/:targetId", missionHandler.DeleteTargetFromMission, can(auth.PermMissionsWrite))
+
+	agency.GET("/search", searchHandler.Search, can(auth.PermMissionsRead))
+	agency.GET("/audit", auditHandler.ListAuditEntries, can(auth.PermAuditRead))
+
+	agencyExport := agency.Group("/export")
+	agencyExport.GET("/cats", exportHandler.ExportCats, can(auth.PermCatsRead))
+	agencyExport.GET("/missions", exportHandler.ExportMissions, can(auth.PermMissionsRead))
+	agencyExport.GET("/targets", exportHandler.ExportTargets, can(auth.PermMissionsRead))
+
+	agencyOutbox := agency.Group("/outbox")
+	agencyOutbox.GET("", outboxHandler.ListOutboxEvents, can(auth.PermOutboxManage))
+	agencyOutbox.POST("/:id/replay", outboxHandler.ReplayOutboxEvent, can(auth.PermOutboxManage))
+
+	agencyWebhooks := agency.Group("/webhooks")
+	agencyWebhooks.POST("", webhookHandler.CreateWebhook, can(auth.PermWebhooksManage))
+	agencyWebhooks.GET("", webhookHandler.ListWebhooks, can(auth.PermWebhooksManage))
+	agencyWebhooks.GET("/:id", webhookHandler.GetWebhook, can(auth.PermWebhooksManage))
+	agencyWebhooks.PATCH("/:id", webhookHandler.UpdateWebhook, can(auth.PermWebhooksManage))
+	agencyWebhooks.DELETE("/:id", webhookHandler.DeleteWebhook, can(auth.PermWebhooksManage))
+	agencyWebhooks.GET("/:id/deliveries", webhookHandler.ListWebhookDeliveries, can(auth.PermWebhooksManage))
+
+	spyCats := api.Group("/spy-cats/:catId", middleware.RequireCat("catId"))
+	spyCats.GET("/mission", missionHandler.GetCatMission, can(auth.PermOwnMissionRead))
+	spyCats.PUT("/mission/targets/:targetId/status", missionHandler.UpdateTargetStatus, can(auth.PermOwnTargetsWrite))
+	spyCats.PUT("/mission/targets/:targetId/notes", missionHandler.UpdateTargetNotes, can(auth.PermOwnTargetsWrite))
+
+	// Go runtime stats and the rate limit rejection counters.
+	e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()), can(auth.PermMetricsRead))
 
 	e.GET("/health", func(c echo.Context) error {
 		return c.JSON(200, map[string]string{"status": "ok"})
@@ -0,0 +1,331 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/api/http/handlers"
+	"spy-cat-agency/internal/api/http/middleware"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/infrastructure/ratelimit"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+)
+
+// caller is one identity the matrix sends requests as. The token doubles as
+// its name.
+type caller struct {
+	token     string
+	principal *auth.Principal
+}
+
+var (
+	ownCatID   = int32(3)
+	otherCatID = int32(4)
+	keyID      = int32(1)
+
+	anonymous = caller{token: "anonymous"}
+	director  = caller{"director", &auth.Principal{Username: "director", Role: auth.RoleDirector}}
+	handler   = caller{"handler", &auth.Principal{Username: "handler", Role: auth.RoleHandler}}
+	finance   = caller{"finance", &auth.Principal{Username: "finance", Role: auth.RoleFinance}}
+	ownCat    = caller{"own-cat", &auth.Principal{Username: "luna", Role: auth.RoleCat, CatID: &ownCatID}}
+	otherCat  = caller{"other-cat", &auth.Principal{Username: "felix", Role: auth.RoleCat, CatID: &otherCatID}}
+	// readerKey is an API key that can read whatever every staff role can.
+	readerKey = caller{"reader-key", &auth.Principal{Username: "reporting", Role: auth.RoleService, APIKeyID: &keyID,
+		Scopes: []auth.Permission{auth.PermCatsRead, auth.PermMissionsRead}}}
+
+	callers = []caller{anonymous, director, handler, finance, ownCat, otherCat, readerKey}
+)
+
+// authorization is the Authorization header the caller sends.
+func (c caller) authorization() string {
+	if c.principal.Role == auth.RoleService {
+		return "ApiKey " + c.token
+	}
+	return "Bearer " + c.token
+}
+
+// routePolicy states who may call a route. Requests use ownCatID wherever a
+// cat ID is in the path. Public routes are open to anonymous callers.
+type routePolicy struct {
+	method  string
+	route   string
+	path    string
+	public  bool
+	allowed []caller
+	// callsCatAPI marks routes whose handler goes straight to TheCatAPI,
+	// which a unit test must not do. Only their registration is checked.
+	callsCatAPI bool
+}
+
+var (
+	staff       = []caller{director, handler, finance, readerKey}
+	operations  = []caller{director, handler}
+	payroll     = []caller{director, finance}
+	directors   = []caller{director}
+	theCatAlone = []caller{ownCat}
+)
+
+var policyMatrix = []routePolicy{
+	{method: "GET", route: "/health", path: "/health", public: true},
+	{method: "GET", route: "/debug/vars", path: "/debug/vars", allowed: directors},
+	{method: "GET", route: "/swagger/*", path: "/swagger/index.html", public: true},
+	{method: "POST", route: "/api/v1/auth/login", path: "/api/v1/auth/login", public: true},
+	{method: "GET", route: "/api/v1/cats", path: "/api/v1/cats", allowed: staff},
+	{method: "GET", route: "/api/v1/cats/:id", path: "/api/v1/cats/3", allowed: staff},
+	{method: "GET", route: "/api/v1/cats/breeds", path: "/api/v1/cats/breeds", public: true, callsCatAPI: true},
+	{method: "GET", route: "/api/v1/cats/:id/missions", path: "/api/v1/cats/3/missions", allowed: staff},
+
+	{method: "POST", route: "/api/v1/agency/staff", path: "/api/v1/agency/staff", allowed: directors},
+
+	{method: "POST", route: "/api/v1/agency/api-keys", path: "/api/v1/agency/api-keys", allowed: directors},
+	{method: "GET", route: "/api/v1/agency/api-keys", path: "/api/v1/agency/api-keys", allowed: directors},
+	{method: "DELETE", route: "/api/v1/agency/api-keys/:id", path: "/api/v1/agency/api-keys/1", allowed: directors},
+
+	{method: "POST", route: "/api/v1/agency/cats", path: "/api/v1/agency/cats", allowed: operations},
+	{method: "POST", route: "/api/v1/agency/cats/import", path: "/api/v1/agency/cats/import", allowed: operations},
+	{method: "PUT", route: "/api/v1/agency/cats/:id/salary", path: "/api/v1/agency/cats/3/salary", allowed: payroll},
+	{method: "PATCH", route: "/api/v1/agency/cats/:id", path: "/api/v1/agency/cats/3", allowed: operations},
+	{method: "DELETE", route: "/api/v1/agency/cats/:id", path: "/api/v1/agency/cats/3", allowed: directors},
+	{method: "PUT", route: "/api/v1/agency/cats/:id/credentials", path: "/api/v1/agency/cats/3/credentials", allowed: directors},
+
+	{method: "POST", route: "/api/v1/agency/missions", path: "/api/v1/agency/missions", allowed: operations},
+	{method: "GET", route: "/api/v1/agency/missions", path: "/api/v1/agency/missions", allowed: staff},
+	{method: "GET", route: "/api/v1/agency/missions/:id", path: "/api/v1/agency/missions/1", allowed: staff},
+	{method: "PATCH", route: "/api/v1/agency/missions/:id", path: "/api/v1/agency/missions/1", allowed: operations},
+	{method: "DELETE", route: "/api/v1/agency/missions/:id", path: "/api/v1/agency/missions/1", allowed: directors},
+	{method: "POST", route: "/api/v1/agency/missions/:id/assign", path: "/api/v1/agency/missions/1/assign", allowed: operations},
+	{method: "POST", route: "/api/v1/agency/missions/:id/abort", path: "/api/v1/agency/missions/1/abort", allowed: operations},
+	{method: "POST", route: "/api/v1/agency/missions/:id/reassign", path: "/api/v1/agency/missions/1/reassign", allowed: operations},
+	{method: "GET", route: "/api/v1/agency/missions/free-cats", path: "/api/v1/agency/missions/free-cats", allowed: staff},
+	{method: "POST", route: "/api/v1/agency/missions/:id/targets", path: "/api/v1/agency/missions/1/targets", allowed: operations},
+	{method: "PUT", route: "/api/v1/agency/missions/:id/targets/:targetId", path: "/api/v1/agency/missions/1/targets/5", allowed: operations},
+	{method: "DELETE", route: "/api/v1/agency/missions/:id/targets/:targetId", path: "/api/v1/agency/missions/1/targets/5", allowed: operations},
+
+	{method: "GET", route: "/api/v1/agency/search", path: "/api/v1/agency/search?q=harbour", allowed: staff},
+	{method: "GET", route: "/api/v1/agency/audit", path: "/api/v1/agency/audit", allowed: directors},
+
+	{method: "GET", route: "/api/v1/agency/export/cats", path: "/api/v1/agency/export/cats", allowed: staff},
+	{method: "GET", route: "/api/v1/agency/export/missions", path: "/api/v1/agency/export/missions", allowed: staff},
+	{method: "GET", route: "/api/v1/agency/export/targets", path: "/api/v1/agency/export/targets", allowed: staff},
+
+	{method: "GET", route: "/api/v1/agency/outbox", path: "/api/v1/agency/outbox", allowed: directors},
+	{method: "POST", route: "/api/v1/agency/outbox/:id/replay", path: "/api/v1/agency/outbox/1/replay", allowed: directors},
+
+	{method: "POST", route: "/api/v1/agency/webhooks", path: "/api/v1/agency/webhooks", allowed: directors},
+	{method: "GET", route: "/api/v1/agency/webhooks", path: "/api/v1/agency/webhooks", allowed: directors},
+	{method: "GET", route: "/api/v1/agency/webhooks/:id", path: "/api/v1/agency/webhooks/1", allowed: directors},
+	{method: "PATCH", route: "/api/v1/agency/webhooks/:id", path: "/api/v1/agency/webhooks/1", allowed: directors},
+	{method: "DELETE", route: "/api/v1/agency/webhooks/:id", path: "/api/v1/agency/webhooks/1", allowed: directors},
+	{method: "GET", route: "/api/v1/agency/webhooks/:id/deliveries", path: "/api/v1/agency/webhooks/1/deliveries", allowed: directors},
+
+	{method: "GET", route: "/api/v1/spy-cats/:catId/mission", path: "/api/v1/spy-cats/3/mission", allowed: theCatAlone},
+	{method: "PUT", route: "/api/v1/spy-cats/:catId/mission/targets/:targetId/status", path: "/api/v1/spy-cats/3/mission/targets/5/status", allowed: theCatAlone},
+	{method: "PUT", route: "/api/v1/spy-cats/:catId/mission/targets/:targetId/notes", path: "/api/v1/spy-cats/3/mission/targets/5/notes", allowed: theCatAlone},
+}
+
+type tokenAuthenticator map[string]*auth.Principal
+
+func (a tokenAuthenticator) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	if principal, ok := a[token]; ok {
+		return principal, nil
+	}
+	return nil, entities.ErrInvalidToken
+}
+
+// newTestServer wires the real routes and policy to handlers without any
+// dependencies. A request the policy lets through reaches a handler that
+// either rejects its empty body or panics on a missing dependency; both are
+// distinguishable from the 401 and 403 the policy answers with.
+func newTestServer() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
+
+	tokens, keys := tokenAuthenticator{}, tokenAuthenticator{}
+	for _, c := range callers {
+		switch {
+		case c.principal == nil:
+		case c.principal.Role == auth.RoleService:
+			keys[c.token] = c.principal
+		default:
+			tokens[c.token] = c.principal
+		}
+	}
+
+	e.Use(echomw.RecoverWithConfig(echomw.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error { return err },
+	}))
+	e.Use(middleware.Authenticate(middleware.Schemes{"Bearer": tokens, "ApiKey": keys}))
+
+	SetupRoutes(e, ratelimit.NewLimiter(ratelimit.Config{}),
+		handlers.NewCatHandler(nil),
+		handlers.NewMissionHandler(nil),
+		handlers.NewOutboxHandler(nil),
+		handlers.NewWebhookHandler(nil),
+		handlers.NewSearchHandler(nil),
+		handlers.NewExportHandler(nil),
+		handlers.NewAuthHandler(nil),
+		handlers.NewAPIKeyHandler(nil),
+		handlers.NewAuditHandler(nil),
+	)
+	return e
+}
+
+func TestPolicyMatrixCoversEveryRoute(t *testing.T) {
+	e := newTestServer()
+
+	declared := make(map[string]bool, len(policyMatrix))
+	for _, policy := range policyMatrix {
+		declared[policy.method+" "+policy.route] = true
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range e.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if !declared[key] {
+			t.Errorf("route %s has no entry in the policy matrix", key)
+		}
+	}
+
+	for key := range declared {
+		if !registered[key] {
+			t.Errorf("policy matrix entry %s is not a registered route", key)
+		}
+	}
+}
+
+func TestPolicyMatrix(t *testing.T) {
+	e := newTestServer()
+
+	for _, policy := range policyMatrix {
+		if policy.callsCatAPI {
+			continue
+		}
+
+		for _, c := range callers {
+			allowed := policy.public || contains(policy.allowed, c)
+
+			req := httptest.NewRequest(policy.method, policy.path, strings.NewReader("{}"))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if c.principal != nil {
+				req.Header.Set(echo.HeaderAuthorization, c.authorization())
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			denied := rec.Code == http.StatusUnauthorized || rec.Code == http.StatusForbidden
+			switch {
+			case allowed && denied:
+				t.Errorf("%s %s as %s: got %d %s, want access", policy.method, policy.path, c.token, rec.Code, rec.Body.String())
+			case !allowed && c.principal == nil && rec.Code != http.StatusUnauthorized:
+				t.Errorf("%s %s as %s: got %d, want 401", policy.method, policy.path, c.token, rec.Code)
+			case !allowed && c.principal != nil && rec.Code != http.StatusForbidden:
+				t.Errorf("%s %s as %s: got %d, want 403", policy.method, policy.path, c.token, rec.Code)
+			}
+		}
+	}
+}
+
+func TestDeniedRequestsGetProblemResponses(t *testing.T) {
+	e := newTestServer()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/agency/cats/3", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+finance.token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", rec.Code)
+	}
+	if contentType := rec.Header().Get(echo.HeaderContentType); contentType != "application/problem+json" {
+		t.Errorf("content type = %q, want application/problem+json", contentType)
+	}
+	if !strings.Contains(rec.Body.String(), `"code":"permission_denied"`) {
+		t.Errorf("body = %s, want code permission_denied", rec.Body.String())
+	}
+}
+
+func TestCredentialsOnlyWorkWithTheirScheme(t *testing.T) {
+	e := newTestServer()
+
+	for _, header := range []string{"Bearer " + readerKey.token, "ApiKey " + director.token, "Basic " + director.token} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/agency/missions", nil)
+		req.Header.Set(echo.HeaderAuthorization, header)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", header, rec.Code)
+		}
+		if challenge := rec.Header().Get(echo.HeaderWWWAuthenticate); challenge != "Bearer, ApiKey" {
+			t.Errorf("%s: WWW-Authenticate = %q, want both schemes", header, challenge)
+		}
+	}
+}
+
+func TestRateLimitedRequestsGet429(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
+	e.Use(echomw.RecoverWithConfig(echomw.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error { return err },
+	}))
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Limits: map[string]ratelimit.Limit{
+		ratelimit.GroupAuth: {Requests: 1, Per: time.Minute, Burst: 1},
+	}})
+	SetupRoutes(e, limiter, nil, nil, nil, nil, nil, nil, handlers.NewAuthHandler(nil), nil, nil)
+
+	login := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("{}"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := login("192.0.2.1:1234"); rec.Code == http.StatusTooManyRequests {
+		t.Fatal("first login was rate limited")
+	}
+
+	rec := login("192.0.2.1:1234")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second login: status = %d, want 429", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"code":"rate_limited"`) {
+		t.Errorf("body = %s, want code rate_limited", rec.Body.String())
+	}
+	wantHeaders := map[string]string{
+		"Retry-After":         "60",
+		"RateLimit-Limit":     "1",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "60",
+	}
+	for name, want := range wantHeaders {
+		if got := rec.Header().Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	if rec := login("198.51.100.7:1234"); rec.Code == http.StatusTooManyRequests {
+		t.Error("a login from another IP was rate limited")
+	}
+}
+
+func contains(callers []caller, wanted caller) bool {
+	for _, c := range callers {
+		if c.token == wanted.token {
+			return true
+		}
+	}
+	return false
+}
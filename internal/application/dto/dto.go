@@ -1,9 +1,13 @@
 package dto
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
 )
 
 type CreateCatRequest struct {
@@ -17,6 +21,16 @@ type UpdateCatSalaryRequest struct {
 	Salary float64 `json:"salary" validate:"required,min=0"`
 }
 
+type UpdateCatProfileRequest struct {
+	Name              *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Breed             *string `json:"breed,omitempty" validate:"omitempty,min=1,max=100"`
+	YearsOfExperience *int32  `json:"years_of_experience,omitempty" validate:"omitempty,min=0"`
+}
+
+func (r *UpdateCatProfileRequest) IsEmpty() bool {
+	return r.Name == nil && r.Breed == nil && r.YearsOfExperience == nil
+}
+
 type CatResponse struct {
 	ID                int32     `json:"id"`
 	Name              string    `json:"name"`
@@ -24,16 +38,32 @@ type CatResponse struct {
 	Breed             string    `json:"breed"`
 	Salary            float64   `json:"salary"`
 	MissionID         *int32    `json:"mission_id,omitempty"`
+	Version           int32     `json:"version"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+func CatFromModel(cat *entities.SpyCat) *CatResponse {
+	return &CatResponse{
+		ID:                cat.ID,
+		Name:              cat.Name,
+		YearsOfExperience: cat.YearsOfExperience,
+		Breed:             cat.Breed,
+		Salary:            cat.Salary,
+		MissionID:         cat.MissionID,
+		Version:           cat.Version,
+		CreatedAt:         cat.CreatedAt,
+		UpdatedAt:         cat.UpdatedAt,
+	}
+}
+
 type CatListResponse struct {
-	Cats   []CatResponse `json:"cats"`
-	Breeds []string      `json:"breeds"`
-	Total  int64         `json:"total"`
-	Limit  int32         `json:"limit"`
-	Offset int32         `json:"offset"`
+	Cats       []CatResponse `json:"cats"`
+	Breeds     []string      `json:"breeds,omitempty"`
+	Total      int64         `json:"total"`
+	Limit      int32         `json:"limit"`
+	Offset     int32         `json:"offset"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
 
 type CreateTargetRequest struct {
@@ -49,10 +79,22 @@ type CreateMissionRequest struct {
 	Targets     []CreateTargetRequest `json:"targets" validate:"required,min=1,max=3,dive"`
 }
 
+type UpdateMissionRequest struct {
+	Name        *string    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,min=1,max=500"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+}
+
+func (r *UpdateMissionRequest) IsEmpty() bool {
+	return r.Name == nil && r.Description == nil && r.StartDate == nil && r.EndDate == nil
+}
+
 func (r *CreateMissionRequest) ToModel() *entities.Mission {
 	mission := &entities.Mission{
 		Name:        r.Name,
 		Description: r.Description,
+		Status:      entities.MissionStatusDraft,
 		IsCompleted: false,
 	}
 
@@ -85,23 +127,51 @@ type TargetResponse struct {
 	Country   string    `json:"country"`
 	Notes     *string   `json:"notes"`
 	Status    string    `json:"status"`
+	Version   int32     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+func TargetFromModel(target *entities.Target) *TargetResponse {
+	return &TargetResponse{
+		ID:        target.ID,
+		MissionID: target.MissionID,
+		Name:      target.Name,
+		Country:   target.Country,
+		Notes:     target.Notes,
+		Status:    string(target.Status),
+		Version:   target.Version,
+		CreatedAt: target.CreatedAt,
+		UpdatedAt: target.UpdatedAt,
+	}
+}
+
 type MissionResponse struct {
-	ID          int32            `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	StartDate   *time.Time       `json:"start_date,omitempty"`
-	EndDate     *time.Time       `json:"end_date,omitempty"`
-	CatID       *int32           `json:"cat_id"`
-	IsCompleted bool             `json:"is_completed"`
-	CompletedAt *time.Time       `json:"completed_at,omitempty"`
-	CreatedAt   time.Time        `json:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at"`
-	Cat         *CatResponse     `json:"cat,omitempty"`
-	Targets     []TargetResponse `json:"targets,omitempty"`
+	ID          int32              `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	StartDate   *time.Time         `json:"start_date,omitempty"`
+	EndDate     *time.Time         `json:"end_date,omitempty"`
+	CatID       *int32             `json:"cat_id"`
+	Status      string             `json:"status"`
+	IsCompleted bool               `json:"is_completed"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	AbortReason *string            `json:"abort_reason,omitempty"`
+	AbortedAt   *time.Time         `json:"aborted_at,omitempty"`
+	Version     int32              `json:"version"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Cat         *CatResponse       `json:"cat,omitempty"`
+	Targets     []TargetResponse   `json:"targets,omitempty"`
+	Handovers   []HandoverResponse `json:"handovers,omitempty"`
+}
+
+type HandoverResponse struct {
+	ID           int32     `json:"id"`
+	FromCatID    int32     `json:"from_cat_id"`
+	ToCatID      int32     `json:"to_cat_id"`
+	Reason       *string   `json:"reason,omitempty"`
+	HandedOverAt time.Time `json:"handed_over_at"`
 }
 
 func MissionFromModel(mission *entities.Mission) *MissionResponse {
@@ -110,18 +180,25 @@ func MissionFromModel(mission *entities.Mission) *MissionResponse {
 		Name:        mission.Name,
 		Description: mission.Description,
 		CatID:       mission.CatID,
+		Status:      string(mission.Status),
 		IsCompleted: mission.IsCompleted,
 		CompletedAt: mission.CompletedAt,
+		AbortReason: mission.AbortReason,
+		AbortedAt:   mission.AbortedAt,
+		Version:     mission.Version,
 		CreatedAt:   mission.CreatedAt,
 		UpdatedAt:   mission.UpdatedAt,
 	}
 
+	// Copies, so the response does not change along with the mission.
 	if !mission.StartDate.IsZero() {
-		response.StartDate = &mission.StartDate
+		startDate := mission.StartDate
+		response.StartDate = &startDate
 	}
 
 	if !mission.EndDate.IsZero() {
-		response.EndDate = &mission.EndDate
+		endDate := mission.EndDate
+		response.EndDate = &endDate
 	}
 
 	if mission.Cat != nil {
@@ -131,6 +208,7 @@ func MissionFromModel(mission *entities.Mission) *MissionResponse {
 			YearsOfExperience: mission.Cat.YearsOfExperience,
 			Breed:             mission.Cat.Breed,
 			Salary:            mission.Cat.Salary,
+			Version:           mission.Cat.Version,
 			CreatedAt:         mission.Cat.CreatedAt,
 			UpdatedAt:         mission.Cat.UpdatedAt,
 		}
@@ -146,20 +224,71 @@ func MissionFromModel(mission *entities.Mission) *MissionResponse {
 				Country:   target.Country,
 				Notes:     target.Notes,
 				Status:    string(target.Status),
+				Version:   target.Version,
 				CreatedAt: target.CreatedAt,
 				UpdatedAt: target.UpdatedAt,
 			}
 		}
 	}
 
+	if len(mission.Handovers) > 0 {
+		response.Handovers = make([]HandoverResponse, len(mission.Handovers))
+		for i, handover := range mission.Handovers {
+			response.Handovers[i] = HandoverResponse{
+				ID:           handover.ID,
+				FromCatID:    handover.FromCatID,
+				ToCatID:      handover.ToCatID,
+				Reason:       handover.Reason,
+				HandedOverAt: handover.HandedOverAt,
+			}
+		}
+	}
+
 	return response
 }
 
 type MissionListResponse struct {
-	Missions []MissionResponse `json:"missions"`
-	Total    int64             `json:"total"`
-	Limit    int32             `json:"limit"`
-	Offset   int32             `json:"offset"`
+	Missions   []MissionResponse `json:"missions"`
+	Total      int64             `json:"total"`
+	Limit      int32             `json:"limit"`
+	Offset     int32             `json:"offset"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+type MissionAssignmentResponse struct {
+	ID            int32      `json:"id"`
+	MissionID     int32      `json:"mission_id"`
+	MissionName   string     `json:"mission_name,omitempty"`
+	MissionStatus string     `json:"mission_status,omitempty"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+	EndReason     *string    `json:"end_reason,omitempty"`
+}
+
+func MissionAssignmentFromModel(assignment *entities.MissionAssignment) MissionAssignmentResponse {
+	response := MissionAssignmentResponse{
+		ID:         assignment.ID,
+		MissionID:  assignment.MissionID,
+		AssignedAt: assignment.AssignedAt,
+		EndedAt:    assignment.EndedAt,
+	}
+	if assignment.EndReason != nil {
+		reason := string(*assignment.EndReason)
+		response.EndReason = &reason
+	}
+	if assignment.Mission != nil {
+		response.MissionName = assignment.Mission.Name
+		response.MissionStatus = string(assignment.Mission.Status)
+	}
+	return response
+}
+
+type MissionAssignmentListResponse struct {
+	Assignments []MissionAssignmentResponse `json:"assignments"`
+	Total       int64                       `json:"total"`
+	Limit       int32                       `json:"limit"`
+	Offset      int32                       `json:"offset"`
+	NextCursor  string                      `json:"next_cursor,omitempty"`
 }
 
 type AddTargetRequest struct {
@@ -172,7 +301,10 @@ type UpdateTargetRequest struct {
 	Name    *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
 	Country *string `json:"country,omitempty" validate:"omitempty,min=1,max=100"`
 	Notes   *string `json:"notes,omitempty"`
-	Status  *string `json:"status,omitempty" validate:"omitempty,oneof=init in_progress completed"`
+}
+
+func (r *UpdateTargetRequest) IsEmpty() bool {
+	return r.Name == nil && r.Country == nil && r.Notes == nil
 }
 
 func (r *AddTargetRequest) ToTargetModel(missionID int32) *entities.Target {
@@ -188,3 +320,454 @@ func (r *AddTargetRequest) ToTargetModel(missionID int32) *entities.Target {
 type AssignCatRequest struct {
 	CatID int32 `json:"cat_id" validate:"required,min=1"`
 }
+
+type AbortMissionRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+type ReassignCatRequest struct {
+	CatID  int32   `json:"cat_id" validate:"required,min=1"`
+	Reason *string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type OutboxEventResponse struct {
+	ID            int64           `json:"id"`
+	EventName     string          `json:"event_name"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	// DeliveredSinks lists the sinks that already accepted the event.
+	DeliveredSinks []string  `json:"delivered_sinks,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func OutboxEventFromModel(event *entities.OutboxEvent) OutboxEventResponse {
+	return OutboxEventResponse{
+		ID:             event.ID,
+		EventName:      event.EventName,
+		Payload:        json.RawMessage(event.Payload),
+		Status:         string(event.Status),
+		Attempts:       event.Attempts,
+		NextAttemptAt:  event.NextAttemptAt,
+		LastError:      event.LastError,
+		OccurredAt:     event.OccurredAt,
+		DeliveredAt:    event.DeliveredAt,
+		DeliveredSinks: event.DeliveredSinkNames(),
+		CreatedAt:      event.CreatedAt,
+	}
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Events      []string `json:"events,omitempty" validate:"omitempty,dive,required"`
+	Secret      *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Events      *[]string `json:"events,omitempty" validate:"omitempty,dive,required"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,max=500"`
+	Active      *bool     `json:"active,omitempty"`
+}
+
+type WebhookResponse struct {
+	ID          int32     `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description *string   `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookFromModel never includes the signing secret; it is only returned
+// once, when the subscription is created.
+func WebhookFromModel(subscription *entities.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:          subscription.ID,
+		URL:         subscription.URL,
+		Events:      subscription.Events(),
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64      `json:"id"`
+	OutboxEventID  int64      `json:"outbox_event_id"`
+	EventName      string     `json:"event_name"`
+	Status         string     `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	LastAttemptAt  time.Time  `json:"last_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+func WebhookDeliveryFromModel(delivery *entities.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		OutboxEventID:  delivery.OutboxEventID,
+		EventName:      delivery.EventName,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+type SearchResultResponse struct {
+	Type      string  `json:"type" example:"target"`
+	ID        int32   `json:"id"`
+	MissionID int32   `json:"mission_id"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet" example:"Tracking his movements near the <mark>harbor</mark>."`
+	Rank      float32 `json:"rank"`
+}
+
+func SearchResultFromHit(hit interfaces.SearchHit) SearchResultResponse {
+	return SearchResultResponse{
+		Type:      string(hit.Type),
+		ID:        hit.ID,
+		MissionID: hit.MissionID,
+		Title:     hit.Title,
+		Snippet:   hit.Snippet,
+		Rank:      hit.Rank,
+	}
+}
+
+type SearchResponse struct {
+	Query   string                 `json:"query"`
+	Results []SearchResultResponse `json:"results"`
+	Total   int64                  `json:"total"`
+	Limit   int32                  `json:"limit"`
+	Offset  int32                  `json:"offset"`
+}
+
+type CatImportRowResult struct {
+	Row    int      `json:"row"`
+	Status string   `json:"status" enums:"created,valid,invalid,failed,skipped"`
+	ID     *int32   `json:"id,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type CatImportResponse struct {
+	Mode    string               `json:"mode" enums:"atomic,partial"`
+	DryRun  bool                 `json:"dry_run"`
+	Total   int                  `json:"total"`
+	Valid   int                  `json:"valid"`
+	Created int                  `json:"created"`
+	Failed  int                  `json:"failed"`
+	Rows    []CatImportRowResult `json:"rows"`
+}
+
+// Export records are flat so every row fits one CSV line; CSVRecord lists the
+// fields in the order of the matching Columns slice.
+
+var CatExportColumns = []string{"id", "name", "breed", "years_of_experience", "salary", "mission_id", "created_at", "updated_at"}
+
+type CatExportRecord struct {
+	ID                int32     `json:"id"`
+	Name              string    `json:"name"`
+	Breed             string    `json:"breed"`
+	YearsOfExperience int32     `json:"years_of_experience"`
+	Salary            float64   `json:"salary"`
+	MissionID         *int32    `json:"mission_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func CatExportFromModel(cat *entities.SpyCat) CatExportRecord {
+	return CatExportRecord{
+		ID:                cat.ID,
+		Name:              cat.Name,
+		Breed:             cat.Breed,
+		YearsOfExperience: cat.YearsOfExperience,
+		Salary:            cat.Salary,
+		MissionID:         cat.MissionID,
+		CreatedAt:         cat.CreatedAt,
+		UpdatedAt:         cat.UpdatedAt,
+	}
+}
+
+func (r CatExportRecord) CSVRecord() []string {
+	return []string{
+		formatInt32(r.ID),
+		formatText(r.Name),
+		formatText(r.Breed),
+		formatInt32(r.YearsOfExperience),
+		strconv.FormatFloat(r.Salary, 'f', 2, 64),
+		formatOptionalInt32(r.MissionID),
+		formatTime(r.CreatedAt),
+		formatTime(r.UpdatedAt),
+	}
+}
+
+var MissionExportColumns = []string{"id", "name", "description", "status", "is_completed", "cat_id", "start_date", "end_date", "completed_at", "aborted_at", "abort_reason", "created_at", "updated_at"}
+
+type MissionExportRecord struct {
+	ID          int32      `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	IsCompleted bool       `json:"is_completed"`
+	CatID       *int32     `json:"cat_id"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	CompletedAt *time.Time `json:"completed_at"`
+	AbortedAt   *time.Time `json:"aborted_at"`
+	AbortReason *string    `json:"abort_reason"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func MissionExportFromModel(mission *entities.Mission) MissionExportRecord {
+	return MissionExportRecord{
+		ID:          mission.ID,
+		Name:        mission.Name,
+		Description: mission.Description,
+		Status:      string(mission.Status),
+		IsCompleted: mission.IsCompleted,
+		CatID:       mission.CatID,
+		StartDate:   mission.StartDate,
+		EndDate:     mission.EndDate,
+		CompletedAt: mission.CompletedAt,
+		AbortedAt:   mission.AbortedAt,
+		AbortReason: mission.AbortReason,
+		CreatedAt:   mission.CreatedAt,
+		UpdatedAt:   mission.UpdatedAt,
+	}
+}
+
+func (r MissionExportRecord) CSVRecord() []string {
+	return []string{
+		formatInt32(r.ID),
+		formatText(r.Name),
+		formatText(r.Description),
+		r.Status,
+		strconv.FormatBool(r.IsCompleted),
+		formatOptionalInt32(r.CatID),
+		formatTime(r.StartDate),
+		formatTime(r.EndDate),
+		formatOptionalTime(r.CompletedAt),
+		formatOptionalTime(r.AbortedAt),
+		formatOptionalText(r.AbortReason),
+		formatTime(r.CreatedAt),
+		formatTime(r.UpdatedAt),
+	}
+}
+
+var TargetExportColumns = []string{"id", "mission_id", "name", "country", "notes", "status", "created_at", "updated_at"}
+
+type TargetExportRecord struct {
+	ID        int32     `json:"id"`
+	MissionID int32     `json:"mission_id"`
+	Name      string    `json:"name"`
+	Country   string    `json:"country"`
+	Notes     *string   `json:"notes"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func TargetExportFromModel(target *entities.Target) TargetExportRecord {
+	return TargetExportRecord{
+		ID:        target.ID,
+		MissionID: target.MissionID,
+		Name:      target.Name,
+		Country:   target.Country,
+		Notes:     target.Notes,
+		Status:    string(target.Status),
+		CreatedAt: target.CreatedAt,
+		UpdatedAt: target.UpdatedAt,
+	}
+}
+
+func (r TargetExportRecord) CSVRecord() []string {
+	return []string{
+		formatInt32(r.ID),
+		formatInt32(r.MissionID),
+		formatText(r.Name),
+		formatText(r.Country),
+		formatOptionalText(r.Notes),
+		r.Status,
+		formatTime(r.CreatedAt),
+		formatTime(r.UpdatedAt),
+	}
+}
+
+func formatInt32(value int32) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+func formatOptionalInt32(value *int32) string {
+	if value == nil {
+		return ""
+	}
+	return formatInt32(*value)
+}
+
+func formatTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return formatTime(*value)
+}
+
+// formatText quotes free text that a spreadsheet would read as a formula,
+// so a name or note starting with =, +, -, @, a tab or a carriage return is
+// shown as written instead of being evaluated.
+func formatText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatOptionalText(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return formatText(*value)
+}
+
+// ProblemResponse is an RFC 7807 problem document. Code is stable and meant
+// for clients to switch on; Detail is for humans and may change.
+type ProblemResponse struct {
+	Type     string `json:"type" example:"urn:spy-cat-agency:problem:mission_not_found"`
+	Title    string `json:"title" example:"Not Found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"mission not found"`
+	Instance string `json:"instance,omitempty" example:"/api/v1/agency/missions/42"`
+	Code     string `json:"code" example:"mission_not_found"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+type LoginResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type" example:"Bearer"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Role        string    `json:"role" example:"director"`
+	CatID       *int32    `json:"cat_id,omitempty"`
+}
+
+// CatCredentialsRequest sets the login a spy cat uses for its field routes.
+type CatCredentialsRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type CreateStaffRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	Role     string `json:"role" validate:"required,oneof=director handler finance" example:"handler"`
+}
+
+type CredentialResponse struct {
+	ID        int32     `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role" example:"cat"`
+	CatID     *int32    `json:"cat_id,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func CredentialFromModel(credential *entities.Credential) *CredentialResponse {
+	return &CredentialResponse{
+		ID:        credential.ID,
+		Username:  credential.Username,
+		Role:      string(credential.Role),
+		CatID:     credential.CatID,
+		UpdatedAt: credential.UpdatedAt,
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100" example:"billing-sync"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required" example:"cats:read,missions:read"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyResponse struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"sca_1a2b3c4d"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	Key        string     `json:"key,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyFromModel never includes the key itself; it is only returned once,
+// when the key is created.
+func APIKeyFromModel(key *entities.APIKey) *APIKeyResponse {
+	scopes := key.Scopes()
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	return &APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     names,
+		CreatedBy:  key.CreatedBy,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+type AuditEntryResponse struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	ActorRole  string          `json:"actor_role" example:"finance"`
+	Action     string          `json:"action" example:"update_salary"`
+	EntityType string          `json:"entity_type" example:"cat"`
+	EntityID   int64           `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func AuditEntryFromModel(entry *entities.AuditEntry) AuditEntryResponse {
+	response := AuditEntryResponse{
+		ID:         entry.ID,
+		Actor:      entry.Actor,
+		ActorRole:  string(entry.ActorRole),
+		Action:     string(entry.Action),
+		EntityType: string(entry.EntityType),
+		EntityID:   entry.EntityID,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.Before != nil {
+		response.Before = json.RawMessage(*entry.Before)
+	}
+	if entry.After != nil {
+		response.After = json.RawMessage(*entry.After)
+	}
+	return response
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+)
+
+func TestExportCSVRecordsQuoteFormulas(t *testing.T) {
+	formulas := []string{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)", "\tcmd", "\rcmd"}
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, formula := range formulas {
+		text := formula
+		want := "'" + formula
+
+		cat := CatExportFromModel(&entities.SpyCat{Name: text, Breed: text}).CSVRecord()
+		mission := MissionExportFromModel(&entities.Mission{Name: text, Description: text, AbortReason: &text, CreatedAt: at}).CSVRecord()
+		target := TargetExportFromModel(&entities.Target{Name: text, Country: text, Notes: &text}).CSVRecord()
+
+		cells := map[string]string{
+			"cat name":             cat[1],
+			"cat breed":            cat[2],
+			"mission name":         mission[1],
+			"mission description":  mission[2],
+			"mission abort reason": mission[10],
+			"target name":          target[2],
+			"target country":       target[3],
+			"target notes":         target[4],
+		}
+		for column, got := range cells {
+			if got != want {
+				t.Errorf("%s = %q, want %q", column, got, want)
+			}
+		}
+	}
+}
+
+func TestExportCSVRecordsKeepPlainText(t *testing.T) {
+	notes := "Met the contact at 10:00; paid 5 fish (-ish)."
+	record := TargetExportFromModel(&entities.Target{Name: "Dr. Fisherman", Country: "Monaco", Notes: &notes}).CSVRecord()
+
+	if record[2] != "Dr. Fisherman" || record[3] != "Monaco" || record[4] != notes {
+		t.Errorf("record = %q, want the text unchanged", record)
+	}
+
+	if record := TargetExportFromModel(&entities.Target{}).CSVRecord(); record[4] != "" {
+		t.Errorf("notes = %q without notes, want empty", record[4])
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+const (
+	// API keys look like sca_<prefix>_<secret>. The prefix finds the key; only
+	// a hash of the whole key is stored.
+	apiKeyMarker = "sca_"
+	// apiKeyUsageGranularity is how stale last_used_at may get before a use
+	// is written back.
+	apiKeyUsageGranularity = time.Minute
+)
+
+// APIKeyService mints and revokes API keys for service-to-service callers and
+// resolves presented keys to principals.
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeyResponse, error)
+	ListAPIKeys(ctx context.Context) ([]*dto.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id int32) (*dto.APIKeyResponse, error)
+	Authenticate(ctx context.Context, key string) (*auth.Principal, error)
+}
+
+type apiKeyService struct {
+	db         database.TransactionManager
+	apiKeyRepo interfaces.APIKeyRepository
+	auditRepo  interfaces.AuditRepository
+}
+
+func NewAPIKeyService(db database.TransactionManager, apiKeyRepo interfaces.APIKeyRepository, auditRepo interfaces.AuditRepository) APIKeyService {
+	return &apiKeyService{
+		db:         db,
+		apiKeyRepo: apiKeyRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+// CreateAPIKey returns the key itself; it cannot be recovered afterwards.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeyResponse, error) {
+	if err := authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+
+	scopes, err := parseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrValidationFailed.WithDetail("expires_at must be in the future")
+	}
+
+	prefix, key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	creator, _ := auth.PrincipalFrom(ctx)
+	apiKey := entities.NewAPIKey(req.Name, prefix, hashAPIKey(key), scopes, creator.Username, req.ExpiresAt)
+
+	err = s.db.RunTransaction(func(tx *gorm.DB) error {
+		if err := s.apiKeyRepo.WithTx(tx).Create(ctx, apiKey); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditCreate, entities.AuditEntityAPIKey, int64(apiKey.ID), nil, dto.APIKeyFromModel(apiKey))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.APIKeyFromModel(apiKey)
+	response.Key = key
+	return response, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]*dto.APIKeyResponse, error) {
+	if err := authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = dto.APIKeyFromModel(key)
+	}
+	return responses, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id int32) (*dto.APIKeyResponse, error) {
+	if err := authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+
+	var response *dto.APIKeyResponse
+
+	err := s.db.RunTransaction(func(tx *gorm.DB) error {
+		key, err := s.apiKeyRepo.WithTx(tx).Revoke(ctx, id, time.Now())
+		if err != nil {
+			return err
+		}
+
+		response = dto.APIKeyFromModel(key)
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditRevoke, entities.AuditEntityAPIKey, int64(id), nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*auth.Principal, error) {
+	prefix, ok := apiKeyPrefix(key)
+	if !ok {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashAPIKey(key))) != 1 {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, entities.ErrInvalidAPIKey
+	}
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now, apiKeyUsageGranularity); err != nil {
+		return nil, err
+	}
+
+	return apiKey.Principal(), nil
+}
+
+func parseAPIKeyScopes(names []string) ([]auth.Permission, error) {
+	scopes := make([]auth.Permission, 0, len(names))
+	seen := make(map[auth.Permission]bool, len(names))
+	for _, name := range names {
+		scope := auth.Permission(name)
+		if !scope.IsAPIKeyScope() {
+			return nil, entities.ErrInvalidAPIKeyScope.WithDetail(fmt.Sprintf("unknown API key scope '%s'", name))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+func generateAPIKey() (prefix, key string, err error) {
+	random := make([]byte, 36)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	prefix = apiKeyMarker + hex.EncodeToString(random[:4])
+	return prefix, prefix + "_" + hex.EncodeToString(random[4:]), nil
+}
+
+// apiKeyPrefix extracts the lookup prefix from a presented key.
+func apiKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyMarker)
+	if !ok {
+		return "", false
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != 8 || len(secret) != 64 {
+		return "", false
+	}
+	return apiKeyMarker + id, true
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
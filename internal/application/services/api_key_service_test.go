@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type fakeAPIKeyRepo struct {
+	interfaces.APIKeyRepository
+	keys    map[string]*entities.APIKey
+	touches int
+}
+
+func (r *fakeAPIKeyRepo) Create(ctx context.Context, key *entities.APIKey) error {
+	key.ID = int32(len(r.keys) + 1)
+	r.keys[key.Prefix] = key
+	return nil
+}
+
+func (r *fakeAPIKeyRepo) WithTx(tx *gorm.DB) interfaces.APIKeyRepository {
+	return r
+}
+
+func (r *fakeAPIKeyRepo) Revoke(ctx context.Context, id int32, at time.Time) (*entities.APIKey, error) {
+	for _, key := range r.keys {
+		if key.ID == id {
+			if key.RevokedAt == nil {
+				key.RevokedAt = &at
+			}
+			return key, nil
+		}
+	}
+	return nil, entities.ErrAPIKeyNotFound
+}
+
+func (r *fakeAPIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	return r.keys[prefix], nil
+}
+
+func (r *fakeAPIKeyRepo) TouchLastUsed(ctx context.Context, id int32, at time.Time, granularity time.Duration) error {
+	r.touches++
+	return nil
+}
+
+func directorContext() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Username: "director", Role: auth.RoleDirector})
+}
+
+func newTestAPIKeyService() (APIKeyService, *fakeAPIKeyRepo, *fakeStore) {
+	store := &fakeStore{}
+	repo := &fakeAPIKeyRepo{keys: map[string]*entities.APIKey{}}
+	return NewAPIKeyService(&fakeTxManager{store: store}, repo, &fakeAuditRepo{store: store}), repo, store
+}
+
+func createTestAPIKey(t *testing.T, scopes ...string) (APIKeyService, *fakeAPIKeyRepo, *dto.APIKeyResponse) {
+	t.Helper()
+	service, repo, _ := newTestAPIKeyService()
+
+	created, err := service.CreateAPIKey(directorContext(), dto.CreateAPIKeyRequest{Name: "billing-sync", Scopes: scopes})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	return service, repo, created
+}
+
+func TestAPIKeyAuthenticatesWithItsScopes(t *testing.T) {
+	service, repo, created := createTestAPIKey(t, "cats:read", "missions:read", "cats:read")
+
+	stored := repo.keys[created.Prefix]
+	if stored == nil || stored.KeyHash == created.Key {
+		t.Fatalf("stored key = %+v, want it stored by prefix as a hash", stored)
+	}
+	if stored.CreatedBy != "director" {
+		t.Errorf("created by = %q, want director", stored.CreatedBy)
+	}
+
+	principal, err := service.Authenticate(context.Background(), created.Key)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if principal.Role != auth.RoleService || principal.APIKeyID == nil || *principal.APIKeyID != created.ID {
+		t.Errorf("principal = %+v, want the service principal of key %d", principal, created.ID)
+	}
+	if !principal.Can(auth.PermMissionsRead) || principal.Can(auth.PermMissionsWrite) {
+		t.Errorf("scopes = %v, want exactly cats:read and missions:read", principal.Scopes)
+	}
+	if len(principal.Scopes) != 2 {
+		t.Errorf("scopes = %v, want duplicates dropped", principal.Scopes)
+	}
+	if repo.touches != 1 {
+		t.Errorf("last-used touches = %d, want 1", repo.touches)
+	}
+}
+
+func TestAPIKeyAuthenticationRejectsBadKeys(t *testing.T) {
+	service, repo, created := createTestAPIKey(t, "cats:read")
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		key    string
+		mutate func(*entities.APIKey)
+	}{
+		{name: "malformed", key: "not-a-key"},
+		{name: "unknown prefix", key: "sca_00000000_" + created.Key[len(created.Prefix)+1:]},
+		{name: "wrong secret", key: created.Prefix + "_" + "0000000000000000000000000000000000000000000000000000000000000000"},
+		{name: "revoked", key: created.Key, mutate: func(k *entities.APIKey) { k.RevokedAt = &past }},
+		{name: "expired", key: created.Key, mutate: func(k *entities.APIKey) { k.ExpiresAt = &past }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := *repo.keys[created.Prefix]
+			if tt.mutate != nil {
+				tt.mutate(repo.keys[created.Prefix])
+				defer func() { *repo.keys[created.Prefix] = stored }()
+			}
+
+			if _, err := service.Authenticate(context.Background(), tt.key); !errors.Is(err, entities.ErrInvalidAPIKey) {
+				t.Errorf("error = %v, want ErrInvalidAPIKey", err)
+			}
+		})
+	}
+	if repo.touches != 0 {
+		t.Errorf("last-used touches = %d, want none for rejected keys", repo.touches)
+	}
+}
+
+func TestCreateAPIKeyRejectsScopesOutsideTheAllowList(t *testing.T) {
+	service, _, _ := newTestAPIKeyService()
+
+	for _, scope := range []string{"staff:manage", "api_keys:manage", "own_mission:read", "cats:everything"} {
+		_, err := service.CreateAPIKey(directorContext(), dto.CreateAPIKeyRequest{Name: "sync", Scopes: []string{scope}})
+		if !errors.Is(err, entities.ErrInvalidAPIKeyScope) {
+			t.Errorf("scope %q: error = %v, want ErrInvalidAPIKeyScope", scope, err)
+		}
+	}
+}
+
+func TestAPIKeyCreationAndRevocationAreAudited(t *testing.T) {
+	service, _, store := newTestAPIKeyService()
+
+	created, err := service.CreateAPIKey(directorContext(), dto.CreateAPIKeyRequest{Name: "billing-sync", Scopes: []string{"cats:read"}})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if _, err := service.RevokeAPIKey(directorContext(), created.ID); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+
+	if len(store.audit) != 2 {
+		t.Fatalf("audit log = %+v, want the creation and the revocation", store.audit)
+	}
+	creation, revocation := store.audit[0], store.audit[1]
+	if creation.Action != entities.AuditCreate || creation.EntityType != entities.AuditEntityAPIKey || creation.EntityID != int64(created.ID) || creation.Actor != "director" {
+		t.Errorf("first audit entry = %+v, want API key %d create by director", creation, created.ID)
+	}
+	if strings.Contains(*creation.After, created.Key) {
+		t.Error("audit entry contains the API key")
+	}
+	if revocation.Action != entities.AuditRevoke || revocation.EntityID != int64(created.ID) || !strings.Contains(*revocation.After, `"revoked_at"`) {
+		t.Errorf("second audit entry = %+v, want API key %d revoke", revocation, created.ID)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// AuditService reads the audit log. Entries are written by the services that
+// make the changes, through recordAudit.
+type AuditService interface {
+	ListAuditEntries(ctx context.Context, filter interfaces.AuditFilter) ([]dto.AuditEntryResponse, error)
+}
+
+type auditService struct {
+	auditRepo interfaces.AuditRepository
+}
+
+func NewAuditService(auditRepo interfaces.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *auditService) ListAuditEntries(ctx context.Context, filter interfaces.AuditFilter) ([]dto.AuditEntryResponse, error) {
+	if err := authorize(ctx, auth.PermAuditRead); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = dto.AuditEntryFromModel(entry)
+	}
+	return responses, nil
+}
+
+// recordAudit logs a change made by the caller in tx, so the entry commits or
+// rolls back with the change. before and after are snapshots of the entity,
+// taken before they can change; pass nil for the side that does not exist.
+func recordAudit(ctx context.Context, auditRepo interfaces.AuditRepository, tx *gorm.DB, action entities.AuditAction, entityType entities.AuditEntityType, entityID int64, before, after any) error {
+	actor, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return entities.ErrAuthRequired
+	}
+
+	beforeJSON, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	entry := entities.NewAuditEntry(actor, action, entityType, entityID, beforeJSON, afterJSON, time.Now())
+	if err := auditRepo.WithTx(tx).Record(ctx, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+func auditSnapshot(state any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+// AuthService checks passwords and hands out access tokens for staff and spy
+// cats. Passwords are only ever stored as bcrypt hashes.
+type AuthService interface {
+	Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error)
+	Authenticate(ctx context.Context, token string) (*auth.Principal, error)
+	SetCatCredentials(ctx context.Context, catID int32, req dto.CatCredentialsRequest) (*dto.CredentialResponse, error)
+	CreateStaff(ctx context.Context, req dto.CreateStaffRequest) (*dto.CredentialResponse, error)
+	// EnsureDirector creates the bootstrap director login unless the username
+	// already exists. It runs at startup, before anyone can log in, so it is
+	// not subject to authorization.
+	EnsureDirector(ctx context.Context, username, password string) error
+}
+
+type authService struct {
+	db             database.TransactionManager
+	credentialRepo interfaces.CredentialRepository
+	catRepo        interfaces.CatRepository
+	auditRepo      interfaces.AuditRepository
+	tokens         auth.TokenIssuer
+	// dummyHash is compared against when the username is unknown, so a failed
+	// login takes as long whether or not the user exists.
+	dummyHash []byte
+}
+
+func NewAuthService(db database.TransactionManager, credentialRepo interfaces.CredentialRepository, catRepo interfaces.CatRepository, auditRepo interfaces.AuditRepository, tokens auth.TokenIssuer) (AuthService, error) {
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("spy-cat-agency"), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare password hashing: %w", err)
+	}
+
+	return &authService{
+		db:             db,
+		credentialRepo: credentialRepo,
+		catRepo:        catRepo,
+		auditRepo:      auditRepo,
+		tokens:         tokens,
+		dummyHash:      dummyHash,
+	}, nil
+}
+
+func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
+	credential, err := s.credentialRepo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential == nil {
+		_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(req.Password))
+		return nil, entities.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.tokens.Issue(credential.Principal())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+		Role:        string(credential.Role),
+		CatID:       credential.CatID,
+	}, nil
+}
+
+func (s *authService) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	return s.tokens.Verify(token)
+}
+
+func (s *authService) SetCatCredentials(ctx context.Context, catID int32, req dto.CatCredentialsRequest) (*dto.CredentialResponse, error) {
+	if err := authorize(ctx, auth.PermStaffManage); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.catRepo.GetByID(ctx, catID); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *dto.CredentialResponse
+
+	err = s.db.RunTransaction(func(tx *gorm.DB) error {
+		credentialRepo := s.credentialRepo.WithTx(tx)
+		credential, err := credentialRepo.GetByCatID(ctx, catID)
+		if err != nil {
+			return err
+		}
+
+		action := entities.AuditCreate
+		var before any
+		if credential == nil {
+			credential = entities.NewCatCredential(catID, req.Username, passwordHash)
+			err = credentialRepo.Create(ctx, credential)
+		} else {
+			action = entities.AuditUpdate
+			before = dto.CredentialFromModel(credential)
+			credential.Username = req.Username
+			credential.PasswordHash = passwordHash
+			err = credentialRepo.Update(ctx, credential)
+		}
+		if err != nil {
+			return err
+		}
+
+		response = dto.CredentialFromModel(credential)
+		return recordAudit(ctx, s.auditRepo, tx, action, entities.AuditEntityCredential, int64(credential.ID), before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *authService) CreateStaff(ctx context.Context, req dto.CreateStaffRequest) (*dto.CredentialResponse, error) {
+	if err := authorize(ctx, auth.PermStaffManage); err != nil {
+		return nil, err
+	}
+
+	role := auth.Role(req.Role)
+	if !role.IsStaff() {
+		return nil, ErrValidationFailed.WithDetail(fmt.Sprintf("role must be one of %v", auth.StaffRoles))
+	}
+
+	passwordHash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *dto.CredentialResponse
+
+	err = s.db.RunTransaction(func(tx *gorm.DB) error {
+		credential := entities.NewStaffCredential(req.Username, passwordHash, role)
+		if err := s.credentialRepo.WithTx(tx).Create(ctx, credential); err != nil {
+			return err
+		}
+
+		response = dto.CredentialFromModel(credential)
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditCreate, entities.AuditEntityCredential, int64(credential.ID), nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *authService) EnsureDirector(ctx context.Context, username, password string) error {
+	existing, err := s.credentialRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if !existing.Role.IsStaff() {
+			return fmt.Errorf("username %q belongs to a spy cat", username)
+		}
+		return nil
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	err = s.credentialRepo.Create(ctx, entities.NewStaffCredential(username, passwordHash, auth.RoleDirector))
+	if errors.Is(err, entities.ErrUsernameTaken) {
+		// Another instance created it first.
+		return nil
+	}
+	return err
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
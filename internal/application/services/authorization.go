@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+)
+
+// authorize checks that the caller on ctx holds the permission. Routes check
+// the same permissions first; this keeps the rule in force for every other way
+// into a service.
+func authorize(ctx context.Context, permission auth.Permission) error {
+	principal, _ := auth.PrincipalFrom(ctx)
+	return entities.Authorize(principal, permission)
+}
+
+// authorizeCat additionally requires the caller to be the given spy cat.
+func authorizeCat(ctx context.Context, catID int32, permission auth.Permission) error {
+	if err := authorize(ctx, permission); err != nil {
+		return err
+	}
+	if principal, _ := auth.PrincipalFrom(ctx); !principal.IsCat(catID) {
+		return entities.ErrNotThisCat
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// guardedCall is one service method together with the permission it needs.
+// The services are built without dependencies: a call that gets past the
+// authorization check would fail on them, so only refusals are exercised.
+type guardedCall struct {
+	name       string
+	permission auth.Permission
+	call       func(ctx context.Context) error
+}
+
+func guardedServiceCalls() []guardedCall {
+	cats := NewCatService(nil, nil, nil, nil, nil, nil)
+	missions := NewMissionService(nil, nil, nil, nil, nil, nil, nil)
+	webhooks := NewWebhookService(nil, nil, nil)
+	search := NewSearchService(nil)
+	export := NewExportService(nil)
+	authService := &authService{}
+	apiKeys := NewAPIKeyService(nil, nil, nil)
+	audit := NewAuditService(nil)
+	outbox := NewOutboxService(nil, nil, nil)
+
+	return []guardedCall{
+		{"CreateCat", auth.PermCatsWrite, func(ctx context.Context) error {
+			_, err := cats.CreateCat(ctx, &entities.SpyCat{})
+			return err
+		}},
+		{"ImportCats", auth.PermCatsWrite, func(ctx context.Context) error {
+			_, err := cats.ImportCats(ctx, nil, CatImportOptions{DryRun: true})
+			return err
+		}},
+		{"GetCat", auth.PermCatsRead, func(ctx context.Context) error {
+			_, err := cats.GetCat(ctx, 1)
+			return err
+		}},
+		{"ListCats", auth.PermCatsRead, func(ctx context.Context) error {
+			_, err := cats.ListCats(ctx, interfaces.CatFilter{})
+			return err
+		}},
+		{"UpdateCatSalary", auth.PermCatsSalary, func(ctx context.Context) error {
+			_, err := cats.UpdateCatSalary(ctx, 1, 100, nil)
+			return err
+		}},
+		{"UpdateCatProfile", auth.PermCatsWrite, func(ctx context.Context) error {
+			_, err := cats.UpdateCatProfile(ctx, 1, dto.UpdateCatProfileRequest{}, nil)
+			return err
+		}},
+		{"DeleteCat", auth.PermCatsDelete, func(ctx context.Context) error {
+			return cats.DeleteCat(ctx, 1, nil)
+		}},
+		{"CreateMission", auth.PermMissionsWrite, func(ctx context.Context) error {
+			_, err := missions.CreateMission(ctx, dto.CreateMissionRequest{})
+			return err
+		}},
+		{"ListMissions", auth.PermMissionsRead, func(ctx context.Context) error {
+			_, err := missions.ListMissions(ctx, interfaces.MissionFilter{})
+			return err
+		}},
+		{"GetMission", auth.PermMissionsRead, func(ctx context.Context) error {
+			_, err := missions.GetMission(ctx, 1)
+			return err
+		}},
+		{"UpdateMission", auth.PermMissionsWrite, func(ctx context.Context) error {
+			_, err := missions.UpdateMission(ctx, 1, dto.UpdateMissionRequest{}, nil)
+			return err
+		}},
+		{"DeleteMission", auth.PermMissionsDelete, func(ctx context.Context) error {
+			return missions.DeleteMission(ctx, 1, nil)
+		}},
+		{"AssignCatToMission", auth.PermMissionsAssign, func(ctx context.Context) error {
+			_, err := missions.AssignCatToMission(ctx, 1, 1)
+			return err
+		}},
+		{"AbortMission", auth.PermMissionsWrite, func(ctx context.Context) error {
+			_, err := missions.AbortMission(ctx, 1, "compromised")
+			return err
+		}},
+		{"ReassignMission", auth.PermMissionsAssign, func(ctx context.Context) error {
+			_, err := missions.ReassignMission(ctx, 1, 2, nil)
+			return err
+		}},
+		{"GetFreeCats", auth.PermCatsRead, func(ctx context.Context) error {
+			_, err := missions.GetFreeCats(ctx)
+			return err
+		}},
+		{"ListCatMissionHistory", auth.PermMissionsRead, func(ctx context.Context) error {
+			_, err := missions.ListCatMissionHistory(ctx, interfaces.MissionAssignmentFilter{CatID: 1})
+			return err
+		}},
+		{"AddTargetToMission", auth.PermMissionsWrite, func(ctx context.Context) error {
+			_, err := missions.AddTargetToMission(ctx, 1, dto.AddTargetRequest{})
+			return err
+		}},
+		{"DeleteTargetFromMission", auth.PermMissionsWrite, func(ctx context.Context) error {
+			return missions.DeleteTargetFromMission(ctx, 1, 1, nil)
+		}},
+		{"UpdateTarget", auth.PermMissionsWrite, func(ctx context.Context) error {
+			_, err := missions.UpdateTarget(ctx, 1, 1, dto.UpdateTargetRequest{}, nil)
+			return err
+		}},
+		{"GetCatMission", auth.PermOwnMissionRead, func(ctx context.Context) error {
+			_, err := missions.GetCatMission(ctx, 99)
+			return err
+		}},
+		{"UpdateTargetStatus", auth.PermOwnTargetsWrite, func(ctx context.Context) error {
+			_, err := missions.UpdateTargetStatus(ctx, 99, 1, "completed", nil)
+			return err
+		}},
+		{"UpdateTargetNotes", auth.PermOwnTargetsWrite, func(ctx context.Context) error {
+			_, err := missions.UpdateTargetNotes(ctx, 99, 1, "notes", nil)
+			return err
+		}},
+		{"CreateWebhook", auth.PermWebhooksManage, func(ctx context.Context) error {
+			_, err := webhooks.CreateWebhook(ctx, dto.CreateWebhookRequest{})
+			return err
+		}},
+		{"ListWebhooks", auth.PermWebhooksManage, func(ctx context.Context) error {
+			_, err := webhooks.ListWebhooks(ctx)
+			return err
+		}},
+		{"GetWebhook", auth.PermWebhooksManage, func(ctx context.Context) error {
+			_, err := webhooks.GetWebhook(ctx, 1)
+			return err
+		}},
+		{"UpdateWebhook", auth.PermWebhooksManage, func(ctx context.Context) error {
+			_, err := webhooks.UpdateWebhook(ctx, 1, dto.UpdateWebhookRequest{})
+			return err
+		}},
+		{"DeleteWebhook", auth.PermWebhooksManage, func(ctx context.Context) error {
+			return webhooks.DeleteWebhook(ctx, 1)
+		}},
+		{"ListDeliveries", auth.PermWebhooksManage, func(ctx context.Context) error {
+			_, err := webhooks.ListDeliveries(ctx, 1, 10, 0)
+			return err
+		}},
+		{"Search", auth.PermMissionsRead, func(ctx context.Context) error {
+			_, err := search.Search(ctx, interfaces.SearchQuery{Text: "harbour"})
+			return err
+		}},
+		{"ExportCats", auth.PermCatsRead, func(ctx context.Context) error {
+			return export.ExportCats(ctx, interfaces.CatFilter{}, func(dto.CatExportRecord) error { return nil })
+		}},
+		{"ExportMissions", auth.PermMissionsRead, func(ctx context.Context) error {
+			return export.ExportMissions(ctx, interfaces.MissionFilter{}, func(dto.MissionExportRecord) error { return nil })
+		}},
+		{"ExportTargets", auth.PermMissionsRead, func(ctx context.Context) error {
+			return export.ExportTargets(ctx, interfaces.MissionFilter{}, func(dto.TargetExportRecord) error { return nil })
+		}},
+		{"SetCatCredentials", auth.PermStaffManage, func(ctx context.Context) error {
+			_, err := authService.SetCatCredentials(ctx, 1, dto.CatCredentialsRequest{})
+			return err
+		}},
+		{"CreateStaff", auth.PermStaffManage, func(ctx context.Context) error {
+			_, err := authService.CreateStaff(ctx, dto.CreateStaffRequest{})
+			return err
+		}},
+		{"ListAuditEntries", auth.PermAuditRead, func(ctx context.Context) error {
+			_, err := audit.ListAuditEntries(ctx, interfaces.AuditFilter{})
+			return err
+		}},
+		{"ListOutboxEvents", auth.PermOutboxManage, func(ctx context.Context) error {
+			_, err := outbox.ListOutboxEvents(ctx, interfaces.OutboxFilter{})
+			return err
+		}},
+		{"ReplayOutboxEvent", auth.PermOutboxManage, func(ctx context.Context) error {
+			_, err := outbox.ReplayOutboxEvent(ctx, 1)
+			return err
+		}},
+		{"CreateAPIKey", auth.PermAPIKeysManage, func(ctx context.Context) error {
+			_, err := apiKeys.CreateAPIKey(ctx, dto.CreateAPIKeyRequest{})
+			return err
+		}},
+		{"ListAPIKeys", auth.PermAPIKeysManage, func(ctx context.Context) error {
+			_, err := apiKeys.ListAPIKeys(ctx)
+			return err
+		}},
+		{"RevokeAPIKey", auth.PermAPIKeysManage, func(ctx context.Context) error {
+			_, err := apiKeys.RevokeAPIKey(ctx, 1)
+			return err
+		}},
+	}
+}
+
+func TestServicesRefuseAnonymousCallers(t *testing.T) {
+	for _, guarded := range guardedServiceCalls() {
+		if err := guarded.call(context.Background()); !errors.Is(err, entities.ErrAuthRequired) {
+			t.Errorf("%s without a principal: error = %v, want ErrAuthRequired", guarded.name, err)
+		}
+	}
+}
+
+func TestServicesRefuseRolesWithoutPermission(t *testing.T) {
+	roles := append([]auth.Role{auth.RoleCat}, auth.StaffRoles...)
+
+	for _, guarded := range guardedServiceCalls() {
+		for _, role := range roles {
+			if role.Can(guarded.permission) {
+				continue
+			}
+
+			catID := int32(1)
+			ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Role: role, CatID: &catID})
+			if err := guarded.call(ctx); !errors.Is(err, entities.ErrPermissionDenied) {
+				t.Errorf("%s as %s: error = %v, want ErrPermissionDenied", guarded.name, role, err)
+			}
+		}
+	}
+}
+
+func TestServicesRefuseAPIKeysWithoutScope(t *testing.T) {
+	for _, guarded := range guardedServiceCalls() {
+		var scopes []auth.Permission
+		for _, scope := range auth.APIKeyScopes {
+			if scope != guarded.permission {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		keyID := int32(1)
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Username: "sync", Role: auth.RoleService, APIKeyID: &keyID, Scopes: scopes})
+		if err := guarded.call(ctx); !errors.Is(err, entities.ErrPermissionDenied) {
+			t.Errorf("%s with every other scope: error = %v, want ErrPermissionDenied", guarded.name, err)
+		}
+	}
+}
+
+func TestCatServicesRefuseOtherCats(t *testing.T) {
+	catID := int32(1)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Role: auth.RoleCat, CatID: &catID})
+
+	for _, guarded := range guardedServiceCalls() {
+		if !auth.RoleCat.Can(guarded.permission) {
+			continue
+		}
+		// Every cat call above targets cat 99.
+		if err := guarded.call(ctx); !errors.Is(err, entities.ErrNotThisCat) {
+			t.Errorf("%s as another cat: error = %v, want ErrNotThisCat", guarded.name, err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/pkg/validator"
+)
+
+var catImportColumns = []string{"name", "breed", "years_of_experience", "salary"}
+
+var catImportValidator = validator.NewValidator()
+
+// BreedLister lists the breeds a spy cat may have.
+type BreedLister interface {
+	GetBreedNames() ([]string, error)
+}
+
+// CatImportRow is one parsed cat of an import; Errors holds what could not be
+// parsed.
+type CatImportRow struct {
+	Cat    dto.CreateCatRequest
+	Errors []string
+}
+
+type CatImportOptions struct {
+	// Atomic creates no cat unless every row is valid and created.
+	Atomic bool
+	// DryRun only validates the rows.
+	DryRun bool
+}
+
+// ParseCatImportJSON reads a JSON array of cats. An element of the wrong shape
+// is reported on its row; anything but an array fails the whole import.
+func ParseCatImportJSON(body []byte) ([]CatImportRow, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of cats")
+	}
+
+	rows := make([]CatImportRow, len(items))
+	for i, item := range items {
+		if err := json.Unmarshal(item, &rows[i].Cat); err != nil {
+			rows[i].Errors = []string{"invalid cat object: please check field types"}
+		}
+	}
+	return rows, nil
+}
+
+// ParseCatImportCSV reads a CSV file whose header names the columns name,
+// breed, years_of_experience and salary in any order. Malformed records and
+// numbers are reported on their row; a bad header fails the whole import.
+func ParseCatImportCSV(body []byte) ([]CatImportRow, error) {
+	reader := csv.NewReader(strings.NewReader(string(body)))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range catImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header must contain the columns %s", strings.Join(catImportColumns, ", "))
+		}
+	}
+
+	var rows []CatImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var row CatImportRow
+		if err != nil {
+			row.Errors = []string{fmt.Sprintf("invalid CSV record: %v", err)}
+			rows = append(rows, row)
+			continue
+		}
+
+		field := func(name string) string {
+			return strings.TrimSpace(record[columns[name]])
+		}
+
+		row.Cat.Name = field("name")
+		row.Cat.Breed = field("breed")
+		if years, err := strconv.ParseInt(field("years_of_experience"), 10, 32); err != nil {
+			row.Errors = append(row.Errors, "Years of experience must be a whole number")
+		} else {
+			row.Cat.YearsOfExperience = int32(years)
+		}
+		if salary, err := strconv.ParseFloat(field("salary"), 64); err != nil {
+			row.Errors = append(row.Errors, "Salary must be a number")
+		} else {
+			row.Cat.Salary = salary
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ImportCats validates every row like CreateCat, with breeds checked against
+// the breed list, and reports each row. In atomic mode one invalid row or
+// failed creation creates none of the cats; otherwise every valid cat is
+// created on its own.
+func (s *catService) ImportCats(ctx context.Context, rows []CatImportRow, options CatImportOptions) (*dto.CatImportResponse, error) {
+	if err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+
+	breeds, err := s.breeds.GetBreedNames()
+	if err != nil {
+		return nil, err
+	}
+	validBreeds := make(map[string]bool, len(breeds))
+	for _, breed := range breeds {
+		validBreeds[breed] = true
+	}
+
+	mode := "partial"
+	if options.Atomic {
+		mode = "atomic"
+	}
+	report := &dto.CatImportResponse{Mode: mode, DryRun: options.DryRun, Total: len(rows), Rows: make([]dto.CatImportRowResult, len(rows))}
+
+	var cats []*entities.SpyCat
+	var positions []int
+	for i, row := range rows {
+		rowErrors := row.Errors
+		if len(rowErrors) == 0 {
+			if err := catImportValidator.Validate(&row.Cat); err != nil {
+				rowErrors = append(rowErrors, err.Error())
+			} else if !validBreeds[row.Cat.Breed] {
+				rowErrors = append(rowErrors, fmt.Sprintf("breed %q is not a TheCatAPI breed", row.Cat.Breed))
+			}
+		}
+
+		report.Rows[i] = dto.CatImportRowResult{Row: i + 1, Name: row.Cat.Name, Status: "valid", Errors: rowErrors}
+		if len(rowErrors) > 0 {
+			report.Rows[i].Status = "invalid"
+			report.Failed++
+			continue
+		}
+
+		report.Valid++
+		cats = append(cats, entities.NewSpyCat(row.Cat.Name, row.Cat.Breed, row.Cat.YearsOfExperience, row.Cat.Salary))
+		positions = append(positions, i)
+	}
+
+	if options.DryRun {
+		return report, nil
+	}
+
+	if options.Atomic && report.Failed > 0 {
+		for _, i := range positions {
+			report.Rows[i].Status = "skipped"
+			report.Rows[i].Errors = []string{entities.ErrImportRolledBack.Error()}
+		}
+		return report, nil
+	}
+
+	errs := s.createCats(ctx, cats, options.Atomic)
+	for j, cat := range cats {
+		result := &report.Rows[positions[j]]
+		switch {
+		case errs[j] == nil:
+			id := cat.ID
+			result.Status = "created"
+			result.ID = &id
+			report.Created++
+		case errors.Is(errs[j], entities.ErrImportRolledBack):
+			result.Status = "skipped"
+			result.Errors = []string{errs[j].Error()}
+		default:
+			result.Status = "failed"
+			result.Errors = []string{"Database error occurred while creating the spy cat"}
+			report.Failed++
+		}
+	}
+
+	return report, nil
+}
+
+// createCats creates a batch of cats and returns one error slot per cat. In
+// atomic mode the batch shares a transaction, so one failure creates none of
+// them; otherwise every cat is created on its own.
+func (s *catService) createCats(ctx context.Context, cats []*entities.SpyCat, atomic bool) []error {
+	errs := make([]error, len(cats))
+
+	if !atomic {
+		for i, cat := range cats {
+			_, errs[i] = s.createCat(ctx, cat)
+		}
+		return errs
+	}
+
+	failed := -1
+	err := runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, func(tx *gorm.DB) ([]events.Event, error) {
+		txCatRepo := s.catRepo.WithTx(tx)
+		published := make([]events.Event, 0, len(cats))
+		for i, cat := range cats {
+			created, err := txCatRepo.Create(ctx, cat)
+			if err != nil {
+				failed = i
+				return nil, err
+			}
+			if err := recordAudit(ctx, s.auditRepo, tx, entities.AuditCreate, entities.AuditEntityCat, int64(created.ID), nil, dto.CatFromModel(created)); err != nil {
+				return nil, err
+			}
+			published = append(published, catCreated(created))
+		}
+		return published, nil
+	})
+
+	if err != nil {
+		for i, cat := range cats {
+			cat.ID = 0
+			if failed >= 0 && i != failed {
+				errs[i] = entities.ErrImportRolledBack
+			} else {
+				errs[i] = err
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+)
+
+type fakeBreeds []string
+
+func (b fakeBreeds) GetBreedNames() ([]string, error) {
+	return b, nil
+}
+
+func newTestCatService(store *fakeStore) (CatService, *recordingPublisher) {
+	publisher := &recordingPublisher{}
+	return NewCatService(
+		&fakeTxManager{store: store},
+		&fakeCatRepo{store: store},
+		&fakeOutboxRepo{store: store},
+		&fakeAuditRepo{store: store},
+		fakeBreeds{"Siamese", "Bengal"},
+		publisher,
+	), publisher
+}
+
+func TestParseCatImportCSV(t *testing.T) {
+	body := "\ufeffSalary, Name ,breed,years_of_experience\n" +
+		"1200.5,Whiskers,Siamese,3\n" +
+		"lots,Shadow,Bengal,two\n" +
+		"900,Tom\n"
+
+	rows, err := ParseCatImportCSV([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseCatImportCSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("rows = %+v, want 3", rows)
+	}
+
+	want := dto.CreateCatRequest{Name: "Whiskers", Breed: "Siamese", YearsOfExperience: 3, Salary: 1200.5}
+	if rows[0].Cat != want || len(rows[0].Errors) != 0 {
+		t.Errorf("row 1 = %+v, want %+v without errors", rows[0], want)
+	}
+	wantErrors := []string{"Years of experience must be a whole number", "Salary must be a number"}
+	if rows[1].Cat.Name != "Shadow" || !reflect.DeepEqual(rows[1].Errors, wantErrors) {
+		t.Errorf("row 2 = %+v, want Shadow with errors %v", rows[1], wantErrors)
+	}
+	if len(rows[2].Errors) != 1 || !strings.HasPrefix(rows[2].Errors[0], "invalid CSV record") {
+		t.Errorf("row 3 = %+v, want an invalid record", rows[2])
+	}
+}
+
+func TestParseCatImportCSVRejectsBadHeaders(t *testing.T) {
+	if _, err := ParseCatImportCSV([]byte("name,breed,salary\nTom,Bengal,900\n")); err == nil {
+		t.Error("header without years_of_experience: want an error")
+	}
+
+	rows, err := ParseCatImportCSV(nil)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("empty body: rows = %+v, error = %v, want neither", rows, err)
+	}
+}
+
+func catImportRows(cats ...dto.CreateCatRequest) []CatImportRow {
+	rows := make([]CatImportRow, len(cats))
+	for i, cat := range cats {
+		rows[i] = CatImportRow{Cat: cat}
+	}
+	return rows
+}
+
+var (
+	whiskers = dto.CreateCatRequest{Name: "Whiskers", Breed: "Siamese", YearsOfExperience: 3, Salary: 1200}
+	shadow   = dto.CreateCatRequest{Name: "Shadow", Breed: "Bengal", YearsOfExperience: 5, Salary: 1500}
+	tom      = dto.CreateCatRequest{Name: "Tom", Breed: "Alley", YearsOfExperience: 1, Salary: 900}
+)
+
+func importStatuses(report *dto.CatImportResponse) []string {
+	statuses := make([]string, len(report.Rows))
+	for i, row := range report.Rows {
+		statuses[i] = row.Status
+	}
+	return statuses
+}
+
+func TestImportCatsAtomicRollsBackWhenACreationFails(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}, failCatCreate: "Shadow"}
+	service, publisher := newTestCatService(store)
+
+	report, err := service.ImportCats(directorContext(), catImportRows(whiskers, shadow), CatImportOptions{Atomic: true})
+	if err != nil {
+		t.Fatalf("ImportCats returned error: %v", err)
+	}
+
+	if got, want := importStatuses(report), []string{"skipped", "failed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("row statuses = %v, want %v", got, want)
+	}
+	if report.Created != 0 || report.Rows[0].ID != nil {
+		t.Errorf("report = %+v, want nothing created", report)
+	}
+	if len(store.cats) != 0 {
+		t.Errorf("cats = %+v after a rolled back import, want none", store.cats)
+	}
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestImportCatsAtomicCreatesNothingWithAnInvalidRow(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}}
+	service, publisher := newTestCatService(store)
+
+	report, err := service.ImportCats(directorContext(), catImportRows(whiskers, tom), CatImportOptions{Atomic: true})
+	if err != nil {
+		t.Fatalf("ImportCats returned error: %v", err)
+	}
+
+	if got, want := importStatuses(report), []string{"skipped", "invalid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("row statuses = %v, want %v", got, want)
+	}
+	if len(store.cats) != 0 {
+		t.Errorf("cats = %+v, want none", store.cats)
+	}
+	assertNothingPublished(t, store, publisher)
+}
+
+func TestImportCatsPartialCreatesTheValidRows(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}, failCatCreate: "Shadow"}
+	service, publisher := newTestCatService(store)
+
+	rows := catImportRows(whiskers, tom, shadow, dto.CreateCatRequest{Breed: "Bengal", Salary: 100})
+	report, err := service.ImportCats(directorContext(), rows, CatImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportCats returned error: %v", err)
+	}
+
+	if got, want := importStatuses(report), []string{"created", "invalid", "failed", "invalid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("row statuses = %v, want %v", got, want)
+	}
+	if report.Mode != "partial" || report.Valid != 2 || report.Created != 1 || report.Failed != 3 {
+		t.Errorf("report = %+v, want 2 valid, 1 created and 3 failed", report)
+	}
+	if id := report.Rows[0].ID; id == nil || store.cats[*id].Name != "Whiskers" {
+		t.Errorf("row 1 id = %v, want the created Whiskers", id)
+	}
+	if errs := report.Rows[1].Errors; len(errs) != 1 || !strings.Contains(errs[0], `breed "Alley"`) {
+		t.Errorf("row 2 errors = %v, want the unknown breed", errs)
+	}
+
+	if names := publisher.names(); len(names) != 1 || names[0] != events.NameCatCreated {
+		t.Errorf("published events = %v, want one %s", names, events.NameCatCreated)
+	}
+	if len(store.audit) != 1 || store.audit[0].EntityType != entities.AuditEntityCat {
+		t.Errorf("audit log = %+v, want the one created cat", store.audit)
+	}
+}
+
+func TestImportCatsDryRunOnlyValidates(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}}
+	service, publisher := newTestCatService(store)
+
+	report, err := service.ImportCats(directorContext(), catImportRows(whiskers, tom), CatImportOptions{Atomic: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportCats returned error: %v", err)
+	}
+
+	if got, want := importStatuses(report), []string{"valid", "invalid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("row statuses = %v, want %v", got, want)
+	}
+	if !report.DryRun || report.Valid != 1 || report.Failed != 1 {
+		t.Errorf("report = %+v, want a dry run with 1 valid and 1 invalid row", report)
+	}
+	if len(store.cats) != 0 {
+		t.Errorf("cats = %+v after a dry run, want none", store.cats)
+	}
+	assertNothingPublished(t, store, publisher)
+}
+
+func TestImportCatsDryRunNeedsPermission(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}}
+	service, _ := newTestCatService(store)
+
+	_, err := service.ImportCats(catContext(7), catImportRows(whiskers), CatImportOptions{DryRun: true})
+	if !errors.Is(err, entities.ErrPermissionDenied) {
+		t.Errorf("error = %v, want ErrPermissionDenied", err)
+	}
+}
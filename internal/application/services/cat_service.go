@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+// CatService owns the spy cat paths so every change is followed by its domain
+// event and audit entry, and every read is checked against cats:read.
+type CatService interface {
+	GetCat(ctx context.Context, id int32) (*entities.SpyCat, error)
+	ListCats(ctx context.Context, filter interfaces.CatFilter) (*dto.CatListResponse, error)
+	CreateCat(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error)
+	// ImportCats validates every row and creates the valid cats unless
+	// options.DryRun is set. The error is set only when the import as a whole
+	// is refused; row problems are in the report.
+	ImportCats(ctx context.Context, rows []CatImportRow, options CatImportOptions) (*dto.CatImportResponse, error)
+	UpdateCatSalary(ctx context.Context, id int32, salary float64, expectedVersion *int32) (*entities.SpyCat, error)
+	UpdateCatProfile(ctx context.Context, id int32, req dto.UpdateCatProfileRequest, expectedVersion *int32) (*entities.SpyCat, error)
+	DeleteCat(ctx context.Context, id int32, expectedVersion *int32) error
+}
+
+type catService struct {
+	db         database.TransactionManager
+	catRepo    interfaces.CatRepository
+	outboxRepo interfaces.OutboxRepository
+	auditRepo  interfaces.AuditRepository
+	breeds     BreedLister
+	publisher  events.Publisher
+}
+
+func NewCatService(db database.TransactionManager, catRepo interfaces.CatRepository, outboxRepo interfaces.OutboxRepository, auditRepo interfaces.AuditRepository, breeds BreedLister, publisher events.Publisher) CatService {
+	return &catService{
+		db:         db,
+		catRepo:    catRepo,
+		outboxRepo: outboxRepo,
+		auditRepo:  auditRepo,
+		breeds:     breeds,
+		publisher:  publisher,
+	}
+}
+
+func (s *catService) GetCat(ctx context.Context, id int32) (*entities.SpyCat, error) {
+	if err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+
+	return s.catRepo.GetByID(ctx, id)
+}
+
+func (s *catService) ListCats(ctx context.Context, filter interfaces.CatFilter) (*dto.CatListResponse, error) {
+	if err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+
+	page := filter
+	page.Limit++
+
+	cats, err := s.catRepo.List(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(cats) > int(filter.Limit) {
+		cats = cats[:filter.Limit]
+		nextCursor = interfaces.CatCursor(cats[len(cats)-1], filter.SortBy, filter.SortDesc).Encode()
+	}
+
+	total, err := s.catRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.CatResponse, len(cats))
+	for i, cat := range cats {
+		responses[i] = *dto.CatFromModel(cat)
+	}
+
+	return &dto.CatListResponse{
+		Cats:       responses,
+		Total:      total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *catService) CreateCat(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error) {
+	if err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBreed(cat.Breed); err != nil {
+		return nil, err
+	}
+
+	return s.createCat(ctx, cat)
+}
+
+// createCat creates a cat whose breed has already been checked.
+func (s *catService) createCat(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error) {
+	var created *entities.SpyCat
+
+	err := runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, func(tx *gorm.DB) ([]events.Event, error) {
+		var err error
+		created, err = s.catRepo.WithTx(tx).Create(ctx, cat)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordAudit(ctx, s.auditRepo, tx, entities.AuditCreate, entities.AuditEntityCat, int64(created.ID), nil, dto.CatFromModel(created)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{catCreated(created)}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// checkBreed rejects breeds TheCatAPI does not know.
+func (s *catService) checkBreed(breed string) error {
+	breeds, err := s.breeds.GetBreedNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range breeds {
+		if name == breed {
+			return nil
+		}
+	}
+	return entities.ErrInvalidBreed
+}
+
+func catCreated(cat *entities.SpyCat) events.CatCreated {
+	return events.CatCreated{
+		CatID:  cat.ID,
+		Name:   cat.Name,
+		Breed:  cat.Breed,
+		Salary: cat.Salary,
+		At:     cat.CreatedAt,
+	}
+}
+
+// UpdateCatSalary locks the cat while the salary changes so the published
+// event carries the salary that was actually replaced.
+func (s *catService) UpdateCatSalary(ctx context.Context, id int32, salary float64, expectedVersion *int32) (*entities.SpyCat, error) {
+	if err := authorize(ctx, auth.PermCatsSalary); err != nil {
+		return nil, err
+	}
+
+	var updated *entities.SpyCat
+
+	err := runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, func(tx *gorm.DB) ([]events.Event, error) {
+		txCatRepo := s.catRepo.WithTx(tx)
+
+		current, err := txCatRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err = txCatRepo.UpdateSalary(ctx, id, salary, expectedVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordAudit(ctx, s.auditRepo, tx, entities.AuditUpdateSalary, entities.AuditEntityCat, int64(id), dto.CatFromModel(current), dto.CatFromModel(updated)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.CatSalaryChanged{
+			CatID:     updated.ID,
+			OldSalary: current.Salary,
+			NewSalary: updated.Salary,
+			At:        updated.UpdatedAt,
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// UpdateCatProfile applies a partial profile change. A new breed must be one
+// TheCatAPI knows.
+func (s *catService) UpdateCatProfile(ctx context.Context, id int32, req dto.UpdateCatProfileRequest, expectedVersion *int32) (*entities.SpyCat, error) {
+	if err := authorize(ctx, auth.PermCatsWrite); err != nil {
+		return nil, err
+	}
+
+	if req.Breed != nil {
+		if err := s.checkBreed(*req.Breed); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *entities.SpyCat
+
+	err := runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, func(tx *gorm.DB) ([]events.Event, error) {
+		txCatRepo := s.catRepo.WithTx(tx)
+
+		spyCat, err := txCatRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := entities.CheckVersion(expectedVersion, spyCat.Version); err != nil {
+			return nil, err
+		}
+
+		before := dto.CatFromModel(spyCat)
+		spyCat.UpdateProfile(req.Name, req.Breed, req.YearsOfExperience)
+
+		updated, err = txCatRepo.UpdateProfile(ctx, spyCat)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordAudit(ctx, s.auditRepo, tx, entities.AuditUpdate, entities.AuditEntityCat, int64(id), before, dto.CatFromModel(updated)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.CatProfileUpdated{
+			CatID:             updated.ID,
+			Name:              updated.Name,
+			Breed:             updated.Breed,
+			YearsOfExperience: updated.YearsOfExperience,
+			At:                updated.UpdatedAt,
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *catService) DeleteCat(ctx context.Context, id int32, expectedVersion *int32) error {
+	if err := authorize(ctx, auth.PermCatsDelete); err != nil {
+		return err
+	}
+
+	return runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, func(tx *gorm.DB) ([]events.Event, error) {
+		txCatRepo := s.catRepo.WithTx(tx)
+
+		current, err := txCatRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := txCatRepo.Delete(ctx, id, expectedVersion); err != nil {
+			return nil, err
+		}
+
+		if err := recordAudit(ctx, s.auditRepo, tx, entities.AuditDelete, entities.AuditEntityCat, int64(id), dto.CatFromModel(current), nil); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.CatDeleted{CatID: id, At: time.Now()}}, nil
+	})
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/entities"
+)
+
+func TestCreateCatChecksTheBreed(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{}}
+	service, publisher := newTestCatService(store)
+
+	_, err := service.CreateCat(directorContext(), entities.NewSpyCat("Tom", "Alley", 1, 900))
+	if !errors.Is(err, entities.ErrInvalidBreed) {
+		t.Fatalf("CreateCat error = %v, want ErrInvalidBreed", err)
+	}
+	if len(store.cats) != 0 {
+		t.Errorf("cats = %+v, want none", store.cats)
+	}
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+
+	created, err := service.CreateCat(directorContext(), entities.NewSpyCat("Whiskers", "Siamese", 3, 1200))
+	if err != nil {
+		t.Fatalf("CreateCat returned error: %v", err)
+	}
+	if store.cats[created.ID].Breed != "Siamese" {
+		t.Errorf("cats = %+v, want the Siamese created", store.cats)
+	}
+}
+
+func TestUpdateCatProfileChecksTheBreed(t *testing.T) {
+	store := &fakeStore{cats: map[int32]entities.SpyCat{
+		1: {ID: 1, Name: "Whiskers", Breed: "Siamese", Version: 1},
+	}}
+	service, publisher := newTestCatService(store)
+
+	breed := "Alley"
+	_, err := service.UpdateCatProfile(directorContext(), 1, dto.UpdateCatProfileRequest{Breed: &breed}, nil)
+	if !errors.Is(err, entities.ErrInvalidBreed) {
+		t.Fatalf("UpdateCatProfile error = %v, want ErrInvalidBreed", err)
+	}
+	if cat := store.cats[1]; cat.Breed != "Siamese" || cat.Version != 1 {
+		t.Errorf("cat = %+v, want it unchanged", cat)
+	}
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+// runWithEvents runs fn in a transaction and appends the events it returns to
+// the outbox in that same transaction, so a change and its events commit or
+// roll back together. In-process subscribers are notified after the commit.
+func runWithEvents(ctx context.Context, db database.TransactionManager, outboxRepo interfaces.OutboxRepository, publisher events.Publisher, fn func(tx *gorm.DB) ([]events.Event, error)) error {
+	var published []events.Event
+
+	err := db.RunTransaction(func(tx *gorm.DB) error {
+		var err error
+		published, err = fn(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := outboxRepo.WithTx(tx).Append(ctx, published...); err != nil {
+			return fmt.Errorf("failed to record events: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	publisher.Publish(ctx, published...)
+	return nil
+}
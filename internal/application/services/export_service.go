@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// ExportService streams full, filtered dumps of the agency data. Pagination
+// fields of the filters are ignored; every matching row is emitted.
+type ExportService interface {
+	ExportCats(ctx context.Context, filter interfaces.CatFilter, emit func(dto.CatExportRecord) error) error
+	ExportMissions(ctx context.Context, filter interfaces.MissionFilter, emit func(dto.MissionExportRecord) error) error
+	ExportTargets(ctx context.Context, filter interfaces.MissionFilter, emit func(dto.TargetExportRecord) error) error
+}
+
+type exportService struct {
+	exportRepo interfaces.ExportRepository
+}
+
+func NewExportService(exportRepo interfaces.ExportRepository) ExportService {
+	return &exportService{
+		exportRepo: exportRepo,
+	}
+}
+
+func (s *exportService) ExportCats(ctx context.Context, filter interfaces.CatFilter, emit func(dto.CatExportRecord) error) error {
+	if err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return err
+	}
+
+	return s.exportRepo.StreamCats(ctx, withoutCatPaging(filter), func(cat *entities.SpyCat) error {
+		return emit(dto.CatExportFromModel(cat))
+	})
+}
+
+func (s *exportService) ExportMissions(ctx context.Context, filter interfaces.MissionFilter, emit func(dto.MissionExportRecord) error) error {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return err
+	}
+
+	return s.exportRepo.StreamMissions(ctx, withoutMissionPaging(filter), func(mission *entities.Mission) error {
+		return emit(dto.MissionExportFromModel(mission))
+	})
+}
+
+func (s *exportService) ExportTargets(ctx context.Context, filter interfaces.MissionFilter, emit func(dto.TargetExportRecord) error) error {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return err
+	}
+
+	return s.exportRepo.StreamTargets(ctx, withoutMissionPaging(filter), func(target *entities.Target) error {
+		return emit(dto.TargetExportFromModel(target))
+	})
+}
+
+func withoutCatPaging(filter interfaces.CatFilter) interfaces.CatFilter {
+	filter.Limit, filter.Offset, filter.After = 0, 0, nil
+	return filter
+}
+
+func withoutMissionPaging(filter interfaces.MissionFilter) interfaces.MissionFilter {
+	filter.Limit, filter.Offset, filter.After = 0, 0, nil
+	return filter
+}
@@ -8,42 +8,67 @@ import (
 	"gorm.io/gorm"
 
 	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
 	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
 	"spy-cat-agency/internal/infrastructure/database"
-	"spy-cat-agency/internal/infrastructure/repositories"
 )
 
 type MissionService interface {
-	CreateMission(req dto.CreateMissionRequest) (*dto.MissionResponse, error)
-	ListMissions() ([]*dto.MissionResponse, error)
-	GetMission(id int32) (*dto.MissionResponse, error)
-	DeleteMission(id int32) error
-	AssignCatToMission(missionID, catID int32) (*dto.MissionResponse, error)
-	GetFreeCats() ([]*dto.CatResponse, error)
-	AddTargetToMission(missionID int32, req dto.AddTargetRequest) (*dto.TargetResponse, error)
-	DeleteTargetFromMission(missionID, targetID int32) error
-	GetCatMission(catID int32) (*dto.MissionResponse, error)
-	UpdateTargetStatus(catID, targetID int32, status string) (*dto.TargetResponse, error)
-	UpdateTargetNotes(catID, targetID int32, notes string) (*dto.TargetResponse, error)
+	CreateMission(ctx context.Context, req dto.CreateMissionRequest) (*dto.MissionResponse, error)
+	ListMissions(ctx context.Context, filter interfaces.MissionFilter) (*dto.MissionListResponse, error)
+	GetMission(ctx context.Context, id int32) (*dto.MissionResponse, error)
+	UpdateMission(ctx context.Context, id int32, req dto.UpdateMissionRequest, expectedVersion *int32) (*dto.MissionResponse, error)
+	DeleteMission(ctx context.Context, id int32, expectedVersion *int32) error
+	AssignCatToMission(ctx context.Context, missionID, catID int32) (*dto.MissionResponse, error)
+	AbortMission(ctx context.Context, missionID int32, reason string) (*dto.MissionResponse, error)
+	ReassignMission(ctx context.Context, missionID, toCatID int32, reason *string) (*dto.MissionResponse, error)
+	GetFreeCats(ctx context.Context) ([]*dto.CatResponse, error)
+	AddTargetToMission(ctx context.Context, missionID int32, req dto.AddTargetRequest) (*dto.TargetResponse, error)
+	DeleteTargetFromMission(ctx context.Context, missionID, targetID int32, expectedVersion *int32) error
+	GetCatMission(ctx context.Context, catID int32) (*dto.MissionResponse, error)
+	ListCatMissionHistory(ctx context.Context, filter interfaces.MissionAssignmentFilter) (*dto.MissionAssignmentListResponse, error)
+	UpdateTarget(ctx context.Context, missionID, targetID int32, req dto.UpdateTargetRequest, expectedVersion *int32) (*dto.TargetResponse, error)
+	UpdateTargetStatus(ctx context.Context, catID, targetID int32, status string, expectedVersion *int32) (*dto.TargetResponse, error)
+	UpdateTargetNotes(ctx context.Context, catID, targetID int32, notes string, expectedVersion *int32) (*dto.TargetResponse, error)
 }
 
 type missionService struct {
-	db          *database.DB
-	missionRepo *repositories.MissionRepository
-	targetRepo  *repositories.TargetRepository
-	catRepo     *repositories.CatRepository
+	db          database.TransactionManager
+	missionRepo interfaces.MissionRepository
+	targetRepo  interfaces.TargetRepository
+	catRepo     interfaces.CatRepository
+	outboxRepo  interfaces.OutboxRepository
+	auditRepo   interfaces.AuditRepository
+	publisher   events.Publisher
 }
 
-func NewMissionService(db *database.DB, missionRepo *repositories.MissionRepository, targetRepo *repositories.TargetRepository, catRepo *repositories.CatRepository) MissionService {
+func NewMissionService(db database.TransactionManager, missionRepo interfaces.MissionRepository, targetRepo interfaces.TargetRepository, catRepo interfaces.CatRepository, outboxRepo interfaces.OutboxRepository, auditRepo interfaces.AuditRepository, publisher events.Publisher) MissionService {
 	return &missionService{
 		db:          db,
 		missionRepo: missionRepo,
 		targetRepo:  targetRepo,
 		catRepo:     catRepo,
+		outboxRepo:  outboxRepo,
+		auditRepo:   auditRepo,
+		publisher:   publisher,
 	}
 }
 
-func (s *missionService) CreateMission(req dto.CreateMissionRequest) (*dto.MissionResponse, error) {
+func (s *missionService) runWithEvents(ctx context.Context, fn func(tx *gorm.DB) ([]events.Event, error)) error {
+	return runWithEvents(ctx, s.db, s.outboxRepo, s.publisher, fn)
+}
+
+func (s *missionService) audit(ctx context.Context, tx *gorm.DB, action entities.AuditAction, entityType entities.AuditEntityType, entityID int32, before, after any) error {
+	return recordAudit(ctx, s.auditRepo, tx, action, entityType, int64(entityID), before, after)
+}
+
+func (s *missionService) CreateMission(ctx context.Context, req dto.CreateMissionRequest) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
 	if err := validateCreateMissionRequest(req); err != nil {
 		return nil, err
 	}
@@ -52,12 +77,12 @@ func (s *missionService) CreateMission(req dto.CreateMissionRequest) (*dto.Missi
 
 	var createdMission *entities.Mission
 
-	err := s.db.RunTransaction(func(tx *gorm.DB) error {
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
 		txMissionRepo := s.missionRepo.WithTx(tx)
 		var err error
 		createdMission, err = txMissionRepo.Create(mission)
 		if err != nil {
-			return fmt.Errorf("failed to create mission: %w", err)
+			return nil, fmt.Errorf("failed to create mission: %w", err)
 		}
 
 		if len(mission.Targets) > 0 {
@@ -67,15 +92,24 @@ func (s *missionService) CreateMission(req dto.CreateMissionRequest) (*dto.Missi
 				mission.Targets[i].Status = entities.TargetStatusInit
 				mission.Targets[i].ID = 0
 
-				_, err := txTargetRepo.Create(context.TODO(), &mission.Targets[i])
+				_, err := txTargetRepo.Create(ctx, &mission.Targets[i])
 				if err != nil {
-					return fmt.Errorf("failed to create target: %w", err)
+					return nil, fmt.Errorf("failed to create target: %w", err)
 				}
 			}
 			createdMission.Targets = mission.Targets
 		}
 
-		return nil
+		if err := s.audit(ctx, tx, entities.AuditCreate, entities.AuditEntityMission, createdMission.ID, nil, dto.MissionFromModel(createdMission)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.MissionCreated{
+			MissionID:   createdMission.ID,
+			Name:        createdMission.Name,
+			TargetCount: len(createdMission.Targets),
+			At:          createdMission.CreatedAt,
+		}}, nil
 	})
 
 	if err != nil {
@@ -85,51 +119,153 @@ func (s *missionService) CreateMission(req dto.CreateMissionRequest) (*dto.Missi
 	return dto.MissionFromModel(createdMission), nil
 }
 
-func (s *missionService) ListMissions() ([]*dto.MissionResponse, error) {
-	missions, err := s.missionRepo.GetAll()
+func (s *missionService) ListMissions(ctx context.Context, filter interfaces.MissionFilter) (*dto.MissionListResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return nil, err
+	}
+
+	page := filter
+	if page.Limit > 0 {
+		page.Limit++
+	}
+
+	missions, err := s.missionRepo.List(page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list missions: %w", err)
 	}
 
-	responses := make([]*dto.MissionResponse, len(missions))
+	var nextCursor string
+	if filter.Limit > 0 && len(missions) > int(filter.Limit) {
+		missions = missions[:filter.Limit]
+		nextCursor = interfaces.MissionCursor(missions[len(missions)-1], filter.SortBy, filter.SortDesc).Encode()
+	}
+
+	total, err := s.missionRepo.Count(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count missions: %w", err)
+	}
+
+	responses := make([]dto.MissionResponse, len(missions))
 	for i, mission := range missions {
-		responses[i] = dto.MissionFromModel(mission)
+		responses[i] = *dto.MissionFromModel(mission)
 	}
 
-	return responses, nil
+	return &dto.MissionListResponse{
+		Missions:   responses,
+		Total:      total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *missionService) GetMission(ctx context.Context, id int32) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return nil, err
+	}
+
+	mission, err := s.missionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MissionFromModel(mission), nil
 }
 
-func (s *missionService) GetMission(id int32) (*dto.MissionResponse, error) {
+func (s *missionService) UpdateMission(ctx context.Context, id int32, req dto.UpdateMissionRequest, expectedVersion *int32) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		txMissionRepo := s.missionRepo.WithTx(tx)
+		mission, err := txMissionRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := entities.CheckVersion(expectedVersion, mission.Version); err != nil {
+			return nil, err
+		}
+
+		if err := validateUpdateMissionRequest(req, mission); err != nil {
+			return nil, err
+		}
+
+		before := dto.MissionFromModel(mission)
+		if err := mission.EditDetails(req.Name, req.Description, req.StartDate, req.EndDate); err != nil {
+			return nil, err
+		}
+
+		if _, err := txMissionRepo.Update(mission); err != nil {
+			return nil, fmt.Errorf("failed to update mission: %w", err)
+		}
+
+		response := dto.MissionFromModel(mission)
+		if err := s.audit(ctx, tx, entities.AuditUpdate, entities.AuditEntityMission, id, before, response); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.MissionUpdated{
+			MissionID:   mission.ID,
+			Name:        mission.Name,
+			Description: mission.Description,
+			StartDate:   response.StartDate,
+			EndDate:     response.EndDate,
+			At:          time.Now(),
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
 	mission, err := s.missionRepo.GetByID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get mission: %w", err)
+		return nil, fmt.Errorf("failed to get updated mission: %w", err)
 	}
 
 	return dto.MissionFromModel(mission), nil
 }
 
-func (s *missionService) DeleteMission(id int32) error {
+func (s *missionService) DeleteMission(ctx context.Context, id int32, expectedVersion *int32) error {
+	if err := authorize(ctx, auth.PermMissionsDelete); err != nil {
+		return err
+	}
+
 	exists, err := s.missionRepo.CheckMissionExists(id)
 	if err != nil {
 		return fmt.Errorf("failed to check mission existence: %w", err)
 	}
 
 	if !exists {
-		return fmt.Errorf("mission with id %d not found", id)
+		return entities.ErrMissionNotFound
 	}
 
-	return s.db.RunTransaction(func(tx *gorm.DB) error {
+	return s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		txMissionRepo := s.missionRepo.WithTx(tx)
+		mission, err := txMissionRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return nil, err
+		}
+
 		txTargetRepo := s.targetRepo.WithTx(tx)
-		if err := txTargetRepo.DeleteByMissionID(context.TODO(), id); err != nil {
-			return fmt.Errorf("failed to delete mission targets: %w", err)
+		if err := txTargetRepo.DeleteByMissionID(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to delete mission targets: %w", err)
 		}
 
-		txMissionRepo := s.missionRepo.WithTx(tx)
-		if err := txMissionRepo.Delete(id); err != nil {
-			return fmt.Errorf("failed to delete mission: %w", err)
+		if err := txMissionRepo.DeleteHistory(id); err != nil {
+			return nil, err
+		}
+
+		if err := txMissionRepo.Delete(id, expectedVersion); err != nil {
+			return nil, fmt.Errorf("failed to delete mission: %w", err)
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditDelete, entities.AuditEntityMission, id, dto.MissionFromModel(mission), nil); err != nil {
+			return nil, err
 		}
 
-		return nil
+		return []events.Event{events.MissionDeleted{MissionID: id, At: time.Now()}}, nil
 	})
 }
 
@@ -151,21 +287,81 @@ func validateCreateMissionRequest(req dto.CreateMissionRequest) error {
 	return nil
 }
 
-func (s *missionService) AssignCatToMission(missionID, catID int32) (*dto.MissionResponse, error) {
-	err := s.db.RunTransaction(func(tx *gorm.DB) error {
-		ctx := context.Background()
+// validateUpdateMissionRequest checks the changed fields and the resulting date
+// range, using the stored dates for whichever side is not being changed.
+func validateUpdateMissionRequest(req dto.UpdateMissionRequest, mission *entities.Mission) error {
+	if req.Name != nil {
+		if err := validateMissionName(*req.Name); err != nil {
+			return err
+		}
+	}
+
+	if req.Description != nil {
+		if err := validateMissionDescription(*req.Description); err != nil {
+			return err
+		}
+	}
+
+	startDate, endDate := mission.StartDate, mission.EndDate
+	if req.StartDate != nil {
+		startDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		endDate = *req.EndDate
+	}
+
+	return validateMissionDates(startDate, endDate)
+}
+
+// AssignCatToMission locks the mission row first and the cat row second, so
+// concurrent assignments of the same cat or mission are serialised and the
+// loser sees the winner's result.
+func (s *missionService) AssignCatToMission(ctx context.Context, missionID, catID int32) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsAssign); err != nil {
+		return nil, err
+	}
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
 
 		txMissionRepo := s.missionRepo.WithTx(tx)
-		if err := txMissionRepo.AssignCatToMission(missionID, catID); err != nil {
-			return fmt.Errorf("failed to assign cat in mission table: %w", err)
+		mission, err := txMissionRepo.GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
 		}
 
 		txCatRepo := s.catRepo.WithTx(tx)
+		cat, err := txCatRepo.GetByIDForUpdate(ctx, catID)
+		if err != nil {
+			return nil, err
+		}
+
+		before := dto.MissionFromModel(mission)
+		assignedAt := time.Now()
+		if err := mission.AssignCat(catID, assignedAt); err != nil {
+			return nil, err
+		}
+
+		if !cat.IsAvailable() {
+			return nil, entities.ErrCatAlreadyAssigned
+		}
+
+		if _, err := txMissionRepo.Update(mission); err != nil {
+			return nil, fmt.Errorf("failed to assign cat in mission table: %w", err)
+		}
+
 		if err := txCatRepo.AssignToMission(ctx, catID, missionID); err != nil {
-			return fmt.Errorf("failed to assign mission to cat: %w", err)
+			return nil, fmt.Errorf("failed to assign mission to cat: %w", err)
 		}
 
-		return nil
+		if err := txMissionRepo.OpenAssignment(entities.NewMissionAssignment(missionID, catID, assignedAt)); err != nil {
+			return nil, err
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditAssign, entities.AuditEntityMission, missionID, before, dto.MissionFromModel(mission)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.CatAssigned{MissionID: missionID, CatID: catID, At: assignedAt}}, nil
 	})
 
 	if err != nil {
@@ -180,7 +376,167 @@ func (s *missionService) AssignCatToMission(missionID, catID int32) (*dto.Missio
 	return dto.MissionFromModel(mission), nil
 }
 
-func (s *missionService) GetFreeCats() ([]*dto.CatResponse, error) {
+func (s *missionService) AbortMission(ctx context.Context, missionID int32, reason string) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+
+		txMissionRepo := s.missionRepo.WithTx(tx)
+		mission, err := txMissionRepo.GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
+		}
+
+		before := dto.MissionFromModel(mission)
+		assignedCatID := mission.CatID
+		previousStatuses := make([]entities.TargetStatus, len(mission.Targets))
+		for i, target := range mission.Targets {
+			previousStatuses[i] = target.Status
+		}
+
+		abortedAt := time.Now()
+		if err := mission.Abort(reason, abortedAt); err != nil {
+			return nil, err
+		}
+
+		published := []events.Event{events.MissionAborted{MissionID: missionID, CatID: assignedCatID, Reason: reason, At: abortedAt}}
+
+		txTargetRepo := s.targetRepo.WithTx(tx)
+		for i := range mission.Targets {
+			target := &mission.Targets[i]
+			if target.Status == previousStatuses[i] {
+				continue
+			}
+			if err := txTargetRepo.UpdateStatus(ctx, target); err != nil {
+				return nil, fmt.Errorf("failed to freeze target: %w", err)
+			}
+			published = append(published, events.TargetStatusChanged{
+				MissionID: missionID,
+				TargetID:  target.ID,
+				From:      previousStatuses[i],
+				To:        target.Status,
+				At:        abortedAt,
+			})
+		}
+
+		if _, err := txMissionRepo.Update(mission); err != nil {
+			return nil, fmt.Errorf("failed to abort mission: %w", err)
+		}
+
+		if assignedCatID != nil {
+			txCatRepo := s.catRepo.WithTx(tx)
+			if err := txCatRepo.UnassignFromMission(ctx, *assignedCatID); err != nil {
+				return nil, fmt.Errorf("failed to release cat from aborted mission: %w", err)
+			}
+			if err := txMissionRepo.CloseAssignment(missionID, entities.AssignmentEndAborted, abortedAt); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditAbort, entities.AuditEntityMission, missionID, before, dto.MissionFromModel(mission)); err != nil {
+			return nil, err
+		}
+
+		return published, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aborted mission: %w", err)
+	}
+
+	return dto.MissionFromModel(mission), nil
+}
+
+func (s *missionService) ReassignMission(ctx context.Context, missionID, toCatID int32, reason *string) (*dto.MissionResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsAssign); err != nil {
+		return nil, err
+	}
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+
+		txMissionRepo := s.missionRepo.WithTx(tx)
+		mission, err := txMissionRepo.GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
+		}
+
+		txCatRepo := s.catRepo.WithTx(tx)
+		incomingCat, err := txCatRepo.GetByIDForUpdate(ctx, toCatID)
+		if err != nil {
+			return nil, err
+		}
+
+		before := dto.MissionFromModel(mission)
+		handover, err := mission.HandOver(toCatID, reason, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if !incomingCat.IsAvailable() {
+			return nil, entities.ErrCatAlreadyAssigned
+		}
+
+		if _, err := txMissionRepo.Update(mission); err != nil {
+			return nil, fmt.Errorf("failed to reassign mission: %w", err)
+		}
+
+		if err := txCatRepo.UnassignFromMission(ctx, handover.FromCatID); err != nil {
+			return nil, fmt.Errorf("failed to release outgoing cat: %w", err)
+		}
+
+		if err := txCatRepo.AssignToMission(ctx, handover.ToCatID, missionID); err != nil {
+			return nil, fmt.Errorf("failed to assign incoming cat: %w", err)
+		}
+
+		if err := txMissionRepo.CreateHandover(handover); err != nil {
+			return nil, err
+		}
+
+		if err := txMissionRepo.CloseAssignment(missionID, entities.AssignmentEndReassigned, handover.HandedOverAt); err != nil {
+			return nil, err
+		}
+
+		if err := txMissionRepo.OpenAssignment(entities.NewMissionAssignment(missionID, handover.ToCatID, handover.HandedOverAt)); err != nil {
+			return nil, err
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditReassign, entities.AuditEntityMission, missionID, before, dto.MissionFromModel(mission)); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.MissionReassigned{
+			MissionID: missionID,
+			FromCatID: handover.FromCatID,
+			ToCatID:   handover.ToCatID,
+			Reason:    handover.Reason,
+			At:        handover.HandedOverAt,
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassigned mission: %w", err)
+	}
+
+	return dto.MissionFromModel(mission), nil
+}
+
+func (s *missionService) GetFreeCats(ctx context.Context) ([]*dto.CatResponse, error) {
+	if err := authorize(ctx, auth.PermCatsRead); err != nil {
+		return nil, err
+	}
+
 	cats, err := s.missionRepo.GetFreeCats()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get free cats: %w", err)
@@ -195,6 +551,7 @@ func (s *missionService) GetFreeCats() ([]*dto.CatResponse, error) {
 			Breed:             cat.Breed,
 			Salary:            cat.Salary,
 			MissionID:         cat.MissionID,
+			Version:           cat.Version,
 			CreatedAt:         cat.CreatedAt,
 			UpdatedAt:         cat.UpdatedAt,
 		})
@@ -203,26 +560,44 @@ func (s *missionService) GetFreeCats() ([]*dto.CatResponse, error) {
 	return responses, nil
 }
 
-func (s *missionService) AddTargetToMission(missionID int32, req dto.AddTargetRequest) (*dto.TargetResponse, error) {
-	mission, err := s.missionRepo.GetByID(missionID)
-	if err != nil {
-		return nil, fmt.Errorf("mission not found: %w", err)
+func (s *missionService) AddTargetToMission(ctx context.Context, missionID int32, req dto.AddTargetRequest) (*dto.TargetResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
 	}
 
-	if !mission.CanAddTarget() {
-		return nil, fmt.Errorf("mission already has the maximum number of targets (%d)", len(mission.Targets))
-	}
+	var createdTarget *entities.Target
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		// The mission row stays locked until commit, so concurrent requests
+		// cannot both pass the target limit or add the same name.
+		mission, err := s.missionRepo.WithTx(tx).GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, target := range mission.Targets {
-		if target.Name == req.Name {
-			return nil, fmt.Errorf("target with name '%s' already exists in this mission", req.Name)
+		if err := mission.CheckNewTarget(req.Name); err != nil {
+			return nil, err
+		}
+
+		createdTarget, err = s.targetRepo.WithTx(tx).Create(ctx, req.ToTargetModel(missionID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create target: %w", err)
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditCreate, entities.AuditEntityTarget, createdTarget.ID, nil, dto.TargetFromModel(createdTarget)); err != nil {
+			return nil, err
 		}
-	}
 
-	target := req.ToTargetModel(missionID)
-	createdTarget, err := s.targetRepo.Create(context.TODO(), target)
+		return []events.Event{events.TargetAdded{
+			MissionID: createdTarget.MissionID,
+			TargetID:  createdTarget.ID,
+			Name:      createdTarget.Name,
+			At:        createdTarget.CreatedAt,
+		}}, nil
+	})
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to create target: %w", err)
+		return nil, err
 	}
 
 	return &dto.TargetResponse{
@@ -232,88 +607,163 @@ func (s *missionService) AddTargetToMission(missionID int32, req dto.AddTargetRe
 		Country:   createdTarget.Country,
 		Notes:     createdTarget.Notes,
 		Status:    string(createdTarget.Status),
+		Version:   createdTarget.Version,
 		CreatedAt: createdTarget.CreatedAt,
 		UpdatedAt: createdTarget.UpdatedAt,
 	}, nil
 }
 
-func (s *missionService) DeleteTargetFromMission(missionID, targetID int32) error {
-	target, err := s.targetRepo.GetByID(context.TODO(), targetID)
-	if err != nil {
-		return fmt.Errorf("target not found: %w", err)
+func (s *missionService) DeleteTargetFromMission(ctx context.Context, missionID, targetID int32, expectedVersion *int32) error {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return err
 	}
 
-	if target.MissionID != missionID {
-		return fmt.Errorf("target does not belong to the specified mission")
-	}
+	return s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		// The mission row stays locked until commit, so concurrent deletes
+		// cannot both pass the minimum target check.
+		mission, err := s.missionRepo.WithTx(tx).GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
+		}
 
-	if target.Status != "init" {
-		return fmt.Errorf("only targets in 'init' status can be deleted")
-	}
+		target := mission.Target(targetID)
+		if target == nil {
+			return nil, entities.ErrTargetNotFound
+		}
 
-	mission, err := s.missionRepo.GetByID(missionID)
-	if err != nil {
-		return fmt.Errorf("mission not found: %w", err)
-	}
+		if err := entities.CheckVersion(expectedVersion, target.Version); err != nil {
+			return nil, err
+		}
 
-	if !mission.HasMinimumTargets() || len(mission.Targets) <= 1 {
-		return fmt.Errorf("mission must have at least one target")
-	}
+		if target.Status != entities.TargetStatusInit {
+			return nil, entities.ErrTargetNotDeletable
+		}
 
-	if err := s.targetRepo.Delete(context.TODO(), targetID); err != nil {
-		return fmt.Errorf("failed to delete target: %w", err)
-	}
+		if !mission.HasMinimumTargets() || len(mission.Targets) <= 1 {
+			return nil, entities.ErrTooFewTargets
+		}
 
-	return nil
+		if err := s.targetRepo.WithTx(tx).Delete(ctx, target); err != nil {
+			return nil, fmt.Errorf("failed to delete target: %w", err)
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditDelete, entities.AuditEntityTarget, targetID, dto.TargetFromModel(target), nil); err != nil {
+			return nil, err
+		}
+
+		return []events.Event{events.TargetDeleted{MissionID: missionID, TargetID: targetID, At: time.Now()}}, nil
+	})
 }
 
-func (s *missionService) GetCatMission(catID int32) (*dto.MissionResponse, error) {
-	missions, err := s.missionRepo.GetAll()
+func (s *missionService) GetCatMission(ctx context.Context, catID int32) (*dto.MissionResponse, error) {
+	if err := authorizeCat(ctx, catID, auth.PermOwnMissionRead); err != nil {
+		return nil, err
+	}
+
+	missions, err := s.missionRepo.List(interfaces.MissionFilter{CatID: &catID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get missions: %w", err)
 	}
 
-	for _, mission := range missions {
-		if mission.CatID != nil && *mission.CatID == catID {
-			return dto.MissionFromModel(mission), nil
-		}
+	if len(missions) == 0 {
+		return nil, nil
 	}
 
-	return nil, nil
+	return dto.MissionFromModel(missions[0]), nil
 }
 
-func (s *missionService) UpdateTargetStatus(catID, targetID int32, status string) (*dto.TargetResponse, error) {
-	target, err := s.targetRepo.GetByID(context.TODO(), targetID)
-	if err != nil {
-		return nil, fmt.Errorf("target not found: %w", err)
+// ListCatMissionHistory lists the missions a cat has worked on, newest
+// assignment first.
+func (s *missionService) ListCatMissionHistory(ctx context.Context, filter interfaces.MissionAssignmentFilter) (*dto.MissionAssignmentListResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return nil, err
 	}
 
-	mission, err := s.missionRepo.GetByID(target.MissionID)
-	if err != nil {
-		return nil, fmt.Errorf("mission not found: %w", err)
+	if _, err := s.catRepo.GetByID(ctx, filter.CatID); err != nil {
+		return nil, err
 	}
 
-	if mission.CatID == nil || *mission.CatID != catID {
-		return nil, fmt.Errorf("target does not belong to the cat's mission")
+	page := filter
+	if page.Limit > 0 {
+		page.Limit++
 	}
 
-	if target.Status == "completed" {
-		return nil, fmt.Errorf("target status is final and cannot be changed")
+	assignments, err := s.missionRepo.ListAssignments(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mission history: %w", err)
 	}
 
-	if err := s.targetRepo.UpdateStatus(context.TODO(), targetID, entities.TargetStatus(status)); err != nil {
-		return nil, fmt.Errorf("failed to update target status: %w", err)
+	var nextCursor string
+	if filter.Limit > 0 && len(assignments) > int(filter.Limit) {
+		assignments = assignments[:filter.Limit]
+		nextCursor = interfaces.AssignmentCursor(assignments[len(assignments)-1]).Encode()
 	}
 
-	updatedTarget, err := s.targetRepo.GetByID(context.TODO(), targetID)
+	total, err := s.missionRepo.CountAssignments(filter.CatID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated target: %w", err)
+		return nil, fmt.Errorf("failed to count mission history: %w", err)
+	}
+
+	responses := make([]dto.MissionAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = dto.MissionAssignmentFromModel(assignment)
 	}
 
-	if status == "completed" {
-		if err := s.checkAndCompleteMission(target.MissionID); err != nil {
-			fmt.Printf("Error checking mission completion: %v\n", err)
+	return &dto.MissionAssignmentListResponse{
+		Assignments: responses,
+		Total:       total,
+		Limit:       filter.Limit,
+		Offset:      filter.Offset,
+		NextCursor:  nextCursor,
+	}, nil
+}
+
+func (s *missionService) UpdateTarget(ctx context.Context, missionID, targetID int32, req dto.UpdateTargetRequest, expectedVersion *int32) (*dto.TargetResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsWrite); err != nil {
+		return nil, err
+	}
+
+	var updatedTarget *entities.Target
+
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		mission, err := s.missionRepo.WithTx(tx).GetByIDForUpdate(missionID)
+		if err != nil {
+			return nil, err
+		}
+
+		var before *dto.TargetResponse
+		if current := mission.Target(targetID); current != nil {
+			if err := entities.CheckVersion(expectedVersion, current.Version); err != nil {
+				return nil, err
+			}
+			before = dto.TargetFromModel(current)
+		}
+
+		target, err := mission.EditTarget(targetID, req.Name, req.Country, req.Notes)
+		if err != nil {
+			return nil, err
+		}
+
+		updatedTarget, err = s.targetRepo.WithTx(tx).Update(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update target: %w", err)
+		}
+
+		if err := s.audit(ctx, tx, entities.AuditUpdate, entities.AuditEntityTarget, targetID, before, dto.TargetFromModel(updatedTarget)); err != nil {
+			return nil, err
 		}
+
+		return []events.Event{events.TargetUpdated{
+			MissionID: mission.ID,
+			TargetID:  updatedTarget.ID,
+			Name:      updatedTarget.Name,
+			Country:   updatedTarget.Country,
+			At:        updatedTarget.UpdatedAt,
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &dto.TargetResponse{
@@ -323,37 +773,107 @@ func (s *missionService) UpdateTargetStatus(catID, targetID int32, status string
 		Country:   updatedTarget.Country,
 		Notes:     updatedTarget.Notes,
 		Status:    string(updatedTarget.Status),
+		Version:   updatedTarget.Version,
 		CreatedAt: updatedTarget.CreatedAt,
 		UpdatedAt: updatedTarget.UpdatedAt,
 	}, nil
 }
 
-func (s *missionService) UpdateTargetNotes(catID, targetID int32, notes string) (*dto.TargetResponse, error) {
-	target, err := s.targetRepo.GetByID(context.TODO(), targetID)
-	if err != nil {
-		return nil, fmt.Errorf("target not found: %w", err)
+// UpdateTargetStatus changes a target status and, when that completes the
+// last open target, completes the mission and releases its cat. Everything
+// runs in one transaction so a failure at any step leaves no partial state.
+func (s *missionService) UpdateTargetStatus(ctx context.Context, catID, targetID int32, status string, expectedVersion *int32) (*dto.TargetResponse, error) {
+	if err := authorizeCat(ctx, catID, auth.PermOwnTargetsWrite); err != nil {
+		return nil, err
 	}
 
-	mission, err := s.missionRepo.GetByID(target.MissionID)
+	nextStatus, err := entities.ParseTargetStatus(status)
 	if err != nil {
-		return nil, fmt.Errorf("mission not found: %w", err)
+		return nil, err
 	}
 
-	if mission.CatID == nil || *mission.CatID != catID {
-		return nil, fmt.Errorf("target does not belong to the cat's mission")
+	if nextStatus == entities.TargetStatusFrozen {
+		return nil, entities.ErrFreezeNotAllowed
 	}
 
-	if target.Status == "completed" {
-		return nil, fmt.Errorf("target is final and cannot be modified")
-	}
+	var updatedTarget *entities.Target
 
-	if err := s.targetRepo.UpdateNotes(context.TODO(), targetID, notes); err != nil {
-		return nil, fmt.Errorf("failed to update target notes: %w", err)
-	}
+	err = s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+
+		txTargetRepo := s.targetRepo.WithTx(tx)
+		txMissionRepo := s.missionRepo.WithTx(tx)
+
+		existing, err := txTargetRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+
+		mission, err := txMissionRepo.GetByIDForUpdate(existing.MissionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if mission.CatID == nil || *mission.CatID != catID {
+			return nil, entities.ErrNotCatsTarget
+		}
+
+		target := mission.Target(targetID)
+		if target == nil {
+			return nil, entities.ErrTargetNotFound
+		}
+
+		if err := entities.CheckVersion(expectedVersion, target.Version); err != nil {
+			return nil, err
+		}
+
+		before := dto.TargetFromModel(target)
+		previousStatus := target.Status
+		if err := target.TransitionTo(nextStatus); err != nil {
+			return nil, err
+		}
+
+		if err := txTargetRepo.UpdateStatus(ctx, target); err != nil {
+			return nil, fmt.Errorf("failed to update target status: %w", err)
+		}
+
+		var published []events.Event
+		if target.Status != previousStatus {
+			if err := s.audit(ctx, tx, entities.AuditUpdateStatus, entities.AuditEntityTarget, target.ID, before, dto.TargetFromModel(target)); err != nil {
+				return nil, err
+			}
+			published = append(published, events.TargetStatusChanged{
+				MissionID: mission.ID,
+				TargetID:  target.ID,
+				From:      previousStatus,
+				To:        target.Status,
+				At:        target.UpdatedAt,
+			})
+		}
+
+		if target.Status != entities.TargetStatusInit && mission.Status == entities.MissionStatusAssigned {
+			if err := mission.Activate(); err != nil {
+				return nil, err
+			}
+			if _, err := txMissionRepo.Update(mission); err != nil {
+				return nil, fmt.Errorf("failed to activate mission: %w", err)
+			}
+		}
+
+		updatedTarget = target
+
+		completed, err := s.completeMissionIfDone(ctx, tx, mission)
+		if err != nil {
+			return nil, err
+		}
+		if completed != nil {
+			published = append(published, *completed)
+		}
+
+		return published, nil
+	})
 
-	updatedTarget, err := s.targetRepo.GetByID(context.TODO(), targetID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated target: %w", err)
+		return nil, err
 	}
 
 	return &dto.TargetResponse{
@@ -363,51 +883,121 @@ func (s *missionService) UpdateTargetNotes(catID, targetID int32, notes string)
 		Country:   updatedTarget.Country,
 		Notes:     updatedTarget.Notes,
 		Status:    string(updatedTarget.Status),
+		Version:   updatedTarget.Version,
 		CreatedAt: updatedTarget.CreatedAt,
 		UpdatedAt: updatedTarget.UpdatedAt,
 	}, nil
 }
 
-func (s *missionService) checkAndCompleteMission(missionID int32) error {
-	mission, err := s.missionRepo.GetByID(missionID)
-	if err != nil {
-		return fmt.Errorf("failed to get mission: %w", err)
+func (s *missionService) UpdateTargetNotes(ctx context.Context, catID, targetID int32, notes string, expectedVersion *int32) (*dto.TargetResponse, error) {
+	if err := authorizeCat(ctx, catID, auth.PermOwnTargetsWrite); err != nil {
+		return nil, err
 	}
 
-	allCompleted := true
-	for _, target := range mission.Targets {
-		if target.Status != "completed" {
-			allCompleted = false
-			break
+	err := s.runWithEvents(ctx, func(tx *gorm.DB) ([]events.Event, error) {
+		txTargetRepo := s.targetRepo.WithTx(tx)
+
+		existing, err := txTargetRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if allCompleted && !mission.IsCompleted {
-		now := time.Now()
+		// Locking the mission keeps a concurrent reassignment from taking the
+		// mission away from the cat between the ownership check and the write.
+		mission, err := s.missionRepo.WithTx(tx).GetByIDForUpdate(existing.MissionID)
+		if err != nil {
+			return nil, err
+		}
 
-		mission.IsCompleted = true
-		mission.CompletedAt = &now
+		if mission.CatID == nil || *mission.CatID != catID {
+			return nil, entities.ErrNotCatsTarget
+		}
 
-		if mission.EndDate.IsZero() {
-			mission.EndDate = now
+		target := mission.Target(targetID)
+		if target == nil {
+			return nil, entities.ErrTargetNotFound
 		}
 
-		assignedCatID := mission.CatID
+		if err := entities.CheckVersion(expectedVersion, target.Version); err != nil {
+			return nil, err
+		}
 
-		mission.CatID = nil
+		if target.IsFinal() {
+			return nil, entities.ErrTargetFinal
+		}
 
-		_, err := s.missionRepo.Update(mission)
-		if err != nil {
-			return fmt.Errorf("failed to complete mission: %w", err)
+		before := dto.TargetFromModel(target)
+		target.Notes = &notes
+		if err := txTargetRepo.UpdateNotes(ctx, target); err != nil {
+			return nil, fmt.Errorf("failed to update target notes: %w", err)
 		}
 
-		if assignedCatID != nil {
-			ctx := context.Background()
-			if err := s.catRepo.UnassignFromMission(ctx, *assignedCatID); err != nil {
-				return fmt.Errorf("failed to unassign cat from completed mission: %w", err)
-			}
+		if err := s.audit(ctx, tx, entities.AuditUpdateNotes, entities.AuditEntityTarget, targetID, before, dto.TargetFromModel(target)); err != nil {
+			return nil, err
 		}
+
+		return []events.Event{events.TargetNotesUpdated{
+			MissionID: target.MissionID,
+			TargetID:  target.ID,
+			CatID:     catID,
+			At:        time.Now(),
+		}}, nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	updatedTarget, err := s.targetRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated target: %w", err)
+	}
+
+	return &dto.TargetResponse{
+		ID:        updatedTarget.ID,
+		MissionID: updatedTarget.MissionID,
+		Name:      updatedTarget.Name,
+		Country:   updatedTarget.Country,
+		Notes:     updatedTarget.Notes,
+		Status:    string(updatedTarget.Status),
+		Version:   updatedTarget.Version,
+		CreatedAt: updatedTarget.CreatedAt,
+		UpdatedAt: updatedTarget.UpdatedAt,
+	}, nil
+}
+
+// completeMissionIfDone completes the mission and releases its cat once every
+// target is completed. It must run inside the caller's transaction and returns
+// the event to publish once that transaction commits.
+func (s *missionService) completeMissionIfDone(ctx context.Context, tx *gorm.DB, mission *entities.Mission) (*events.MissionCompleted, error) {
+	if mission.IsFinished() || !mission.AllTargetsCompleted() {
+		return nil, nil
+	}
+
+	before := dto.MissionFromModel(mission)
+	assignedCatID := mission.CatID
+
+	if err := mission.Complete(time.Now()); err != nil {
+		return nil, err
+	}
+
+	txMissionRepo := s.missionRepo.WithTx(tx)
+	if _, err := txMissionRepo.Update(mission); err != nil {
+		return nil, fmt.Errorf("failed to complete mission: %w", err)
+	}
+
+	if assignedCatID != nil {
+		if err := s.catRepo.WithTx(tx).UnassignFromMission(ctx, *assignedCatID); err != nil {
+			return nil, fmt.Errorf("failed to unassign cat from completed mission: %w", err)
+		}
+		if err := txMissionRepo.CloseAssignment(mission.ID, entities.AssignmentEndCompleted, *mission.CompletedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.audit(ctx, tx, entities.AuditComplete, entities.AuditEntityMission, mission.ID, before, dto.MissionFromModel(mission)); err != nil {
+		return nil, err
+	}
+
+	return &events.MissionCompleted{MissionID: mission.ID, CatID: assignedCatID, At: *mission.CompletedAt}, nil
 }
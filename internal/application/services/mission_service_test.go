@@ -0,0 +1,1041 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// fakeStore is an in-memory stand-in for the database. fakeTxManager snapshots
+// it before each transaction and restores the snapshot when the transaction
+// fails, which is enough to observe all-or-nothing behaviour.
+type fakeStore struct {
+	missions map[int32]entities.Mission
+	targets  map[int32]entities.Target
+	cats     map[int32]entities.SpyCat
+	outbox   []string
+	audit    []entities.AuditEntry
+
+	assignments []entities.MissionAssignment
+	handovers   []entities.MissionHandover
+
+	// locks lists the rows read through the ForUpdate repo methods, in order.
+	locks []string
+
+	failMissionUpdate bool
+	failCatUnassign   bool
+	failHandover      bool
+	// failCatCreate names a cat whose creation fails.
+	failCatCreate string
+}
+
+func (s *fakeStore) clone() fakeStore {
+	c := fakeStore{
+		missions: make(map[int32]entities.Mission, len(s.missions)),
+		targets:  make(map[int32]entities.Target, len(s.targets)),
+		cats:     make(map[int32]entities.SpyCat, len(s.cats)),
+		outbox:   append([]string(nil), s.outbox...),
+		audit:    append([]entities.AuditEntry(nil), s.audit...),
+
+		assignments: append([]entities.MissionAssignment(nil), s.assignments...),
+		handovers:   append([]entities.MissionHandover(nil), s.handovers...),
+	}
+	for id, m := range s.missions {
+		c.missions[id] = m
+	}
+	for id, t := range s.targets {
+		c.targets[id] = t
+	}
+	for id, cat := range s.cats {
+		c.cats[id] = cat
+	}
+	return c
+}
+
+type fakeTxManager struct {
+	store *fakeStore
+}
+
+func (m *fakeTxManager) RunTransaction(fn func(tx *gorm.DB) error) error {
+	snapshot := m.store.clone()
+	if err := fn(nil); err != nil {
+		m.store.missions = snapshot.missions
+		m.store.targets = snapshot.targets
+		m.store.cats = snapshot.cats
+		m.store.outbox = snapshot.outbox
+		m.store.audit = snapshot.audit
+		m.store.assignments = snapshot.assignments
+		m.store.handovers = snapshot.handovers
+		return err
+	}
+	return nil
+}
+
+type fakeMissionRepo struct {
+	interfaces.MissionRepository
+	store *fakeStore
+}
+
+func (r *fakeMissionRepo) WithTx(tx *gorm.DB) interfaces.MissionRepository {
+	return r
+}
+
+func (r *fakeMissionRepo) GetByIDForUpdate(id int32) (*entities.Mission, error) {
+	r.store.locks = append(r.store.locks, fmt.Sprintf("mission %d", id))
+	return r.get(id)
+}
+
+func (r *fakeMissionRepo) get(id int32) (*entities.Mission, error) {
+	mission, ok := r.store.missions[id]
+	if !ok {
+		return nil, entities.ErrMissionNotFound
+	}
+
+	mission.Targets = nil
+	for _, target := range r.store.targets {
+		if target.MissionID == id {
+			mission.Targets = append(mission.Targets, target)
+		}
+	}
+	sort.Slice(mission.Targets, func(i, j int) bool { return mission.Targets[i].ID < mission.Targets[j].ID })
+
+	return &mission, nil
+}
+
+func (r *fakeMissionRepo) GetByID(id int32) (*entities.Mission, error) {
+	return r.get(id)
+}
+
+func (r *fakeMissionRepo) Update(mission *entities.Mission) (*entities.Mission, error) {
+	if r.store.failMissionUpdate {
+		return nil, errors.New("mission update failed")
+	}
+
+	stored := r.store.missions[mission.ID]
+	if stored.Version != mission.Version {
+		return nil, entities.ErrVersionConflict
+	}
+
+	mission.Version++
+	saved := *mission
+	saved.Targets = nil
+	r.store.missions[mission.ID] = saved
+	return mission, nil
+}
+
+func (r *fakeMissionRepo) CreateHandover(handover *entities.MissionHandover) error {
+	if r.store.failHandover {
+		return errors.New("handover record failed")
+	}
+
+	handover.ID = int32(len(r.store.handovers) + 1)
+	r.store.handovers = append(r.store.handovers, *handover)
+	return nil
+}
+
+func (r *fakeMissionRepo) OpenAssignment(assignment *entities.MissionAssignment) error {
+	assignment.ID = int32(len(r.store.assignments) + 1)
+	r.store.assignments = append(r.store.assignments, *assignment)
+	return nil
+}
+
+func (r *fakeMissionRepo) CloseAssignment(missionID int32, reason entities.AssignmentEndReason, at time.Time) error {
+	for i, assignment := range r.store.assignments {
+		if assignment.MissionID == missionID && assignment.EndedAt == nil {
+			assignment.EndedAt = &at
+			assignment.EndReason = &reason
+			r.store.assignments[i] = assignment
+		}
+	}
+	return nil
+}
+
+func (r *fakeMissionRepo) CheckMissionExists(id int32) (bool, error) {
+	_, ok := r.store.missions[id]
+	return ok, nil
+}
+
+func (r *fakeMissionRepo) Delete(id int32, expectedVersion *int32) error {
+	mission, ok := r.store.missions[id]
+	if !ok {
+		return entities.ErrMissionNotFound
+	}
+	if err := entities.CheckVersion(expectedVersion, mission.Version); err != nil {
+		return err
+	}
+	if mission.CatID != nil {
+		return entities.ErrMissionStaffed
+	}
+
+	// Like the foreign keys in Postgres, refuse to orphan the mission's history.
+	for _, assignment := range r.store.assignments {
+		if assignment.MissionID == id {
+			return errors.New("mission_assignments still reference the mission")
+		}
+	}
+	for _, handover := range r.store.handovers {
+		if handover.MissionID == id {
+			return errors.New("mission_handovers still reference the mission")
+		}
+	}
+
+	delete(r.store.missions, id)
+	return nil
+}
+
+func (r *fakeMissionRepo) DeleteHistory(missionID int32) error {
+	assignments := r.store.assignments[:0:0]
+	for _, assignment := range r.store.assignments {
+		if assignment.MissionID != missionID {
+			assignments = append(assignments, assignment)
+		}
+	}
+	handovers := r.store.handovers[:0:0]
+	for _, handover := range r.store.handovers {
+		if handover.MissionID != missionID {
+			handovers = append(handovers, handover)
+		}
+	}
+	r.store.assignments, r.store.handovers = assignments, handovers
+	return nil
+}
+
+type fakeTargetRepo struct {
+	interfaces.TargetRepository
+	store *fakeStore
+}
+
+func (r *fakeTargetRepo) WithTx(tx *gorm.DB) interfaces.TargetRepository {
+	return r
+}
+
+func (r *fakeTargetRepo) GetByID(ctx context.Context, id int32) (*entities.Target, error) {
+	target, ok := r.store.targets[id]
+	if !ok {
+		return nil, entities.ErrTargetNotFound
+	}
+	return &target, nil
+}
+
+func (r *fakeTargetRepo) UpdateStatus(ctx context.Context, target *entities.Target) error {
+	stored := r.store.targets[target.ID]
+	if stored.Version != target.Version {
+		return entities.ErrVersionConflict
+	}
+
+	stored.Status = target.Status
+	stored.Version++
+	r.store.targets[target.ID] = stored
+	target.Version = stored.Version
+	return nil
+}
+
+func (r *fakeTargetRepo) UpdateNotes(ctx context.Context, target *entities.Target) error {
+	stored := r.store.targets[target.ID]
+	if stored.Version != target.Version {
+		return entities.ErrVersionConflict
+	}
+
+	stored.Notes = target.Notes
+	stored.Version++
+	r.store.targets[target.ID] = stored
+	target.Version = stored.Version
+	return nil
+}
+
+func (r *fakeTargetRepo) Create(ctx context.Context, target *entities.Target) (*entities.Target, error) {
+	target.ID = int32(len(r.store.targets) + 1)
+	target.Version = 1
+	r.store.targets[target.ID] = *target
+	return target, nil
+}
+
+func (r *fakeTargetRepo) Delete(ctx context.Context, target *entities.Target) error {
+	stored, ok := r.store.targets[target.ID]
+	if !ok || stored.Version != target.Version {
+		return entities.ErrVersionConflict
+	}
+
+	delete(r.store.targets, target.ID)
+	return nil
+}
+
+func (r *fakeTargetRepo) DeleteByMissionID(ctx context.Context, missionID int32) error {
+	for id, target := range r.store.targets {
+		if target.MissionID == missionID {
+			delete(r.store.targets, id)
+		}
+	}
+	return nil
+}
+
+type fakeCatRepo struct {
+	interfaces.CatRepository
+	store *fakeStore
+}
+
+func (r *fakeCatRepo) WithTx(tx *gorm.DB) interfaces.CatRepository {
+	return r
+}
+
+func (r *fakeCatRepo) Create(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error) {
+	if cat.Name == r.store.failCatCreate {
+		return nil, errors.New("cat creation failed")
+	}
+
+	cat.ID = int32(len(r.store.cats) + 1)
+	cat.Version = 1
+	r.store.cats[cat.ID] = *cat
+	return cat, nil
+}
+
+func (r *fakeCatRepo) GetByIDForUpdate(ctx context.Context, id int32) (*entities.SpyCat, error) {
+	r.store.locks = append(r.store.locks, fmt.Sprintf("cat %d", id))
+	cat, ok := r.store.cats[id]
+	if !ok {
+		return nil, entities.ErrCatNotFound
+	}
+	return &cat, nil
+}
+
+func (r *fakeCatRepo) AssignToMission(ctx context.Context, catID, missionID int32) error {
+	cat := r.store.cats[catID]
+	cat.MissionID = &missionID
+	cat.Version++
+	r.store.cats[catID] = cat
+	return nil
+}
+
+func (r *fakeCatRepo) UnassignFromMission(ctx context.Context, catID int32) error {
+	if r.store.failCatUnassign {
+		return errors.New("cat release failed")
+	}
+
+	cat := r.store.cats[catID]
+	cat.MissionID = nil
+	cat.Version++
+	r.store.cats[catID] = cat
+	return nil
+}
+
+type fakeOutboxRepo struct {
+	interfaces.OutboxRepository
+	store *fakeStore
+}
+
+func (r *fakeOutboxRepo) WithTx(tx *gorm.DB) interfaces.OutboxRepository {
+	return r
+}
+
+func (r *fakeOutboxRepo) Append(ctx context.Context, published ...events.Event) error {
+	for _, event := range published {
+		r.store.outbox = append(r.store.outbox, event.EventName())
+	}
+	return nil
+}
+
+type fakeAuditRepo struct {
+	interfaces.AuditRepository
+	store *fakeStore
+}
+
+func (r *fakeAuditRepo) WithTx(tx *gorm.DB) interfaces.AuditRepository {
+	return r
+}
+
+func (r *fakeAuditRepo) Record(ctx context.Context, entries ...*entities.AuditEntry) error {
+	for _, entry := range entries {
+		r.store.audit = append(r.store.audit, *entry)
+	}
+	return nil
+}
+
+type recordingPublisher struct {
+	published []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, published ...events.Event) {
+	p.published = append(p.published, published...)
+}
+
+func (p *recordingPublisher) names() []string {
+	names := make([]string, len(p.published))
+	for i, event := range p.published {
+		names[i] = event.EventName()
+	}
+	return names
+}
+
+// catContext is the context of a request made by the given spy cat.
+func catContext(catID int32) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Role: auth.RoleCat, CatID: &catID})
+}
+
+func newTestMissionService(store *fakeStore) (MissionService, *recordingPublisher) {
+	publisher := &recordingPublisher{}
+	return NewMissionService(
+		&fakeTxManager{store: store},
+		&fakeMissionRepo{store: store},
+		&fakeTargetRepo{store: store},
+		&fakeCatRepo{store: store},
+		&fakeOutboxRepo{store: store},
+		&fakeAuditRepo{store: store},
+		publisher,
+	), publisher
+}
+
+// newActiveMissionStore returns a mission staffed by cat 7 with one completed
+// target (1) and one target still in progress (2).
+func newActiveMissionStore() *fakeStore {
+	catID := int32(7)
+	missionID := int32(1)
+
+	return &fakeStore{
+		missions: map[int32]entities.Mission{
+			missionID: {ID: missionID, Name: "Operation Goldfish", CatID: &catID, Status: entities.MissionStatusActive, Version: 1},
+		},
+		targets: map[int32]entities.Target{
+			1: {ID: 1, MissionID: missionID, Name: "Dr. Fisherman", Status: entities.TargetStatusCompleted, Version: 1},
+			2: {ID: 2, MissionID: missionID, Name: "Captain Aquarius", Status: entities.TargetStatusInProgress, Version: 1},
+		},
+		cats: map[int32]entities.SpyCat{
+			catID: {ID: catID, Name: "Jane", MissionID: &missionID, Version: 1},
+		},
+		assignments: []entities.MissionAssignment{
+			{ID: 1, MissionID: missionID, CatID: catID, AssignedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+func TestUpdateTargetStatusCompletesMissionAndReleasesCat(t *testing.T) {
+	store := newActiveMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	target, err := service.UpdateTargetStatus(catContext(7), 7, 2, "completed", nil)
+	if err != nil {
+		t.Fatalf("UpdateTargetStatus returned error: %v", err)
+	}
+
+	if target.Status != string(entities.TargetStatusCompleted) {
+		t.Errorf("target status = %q, want %q", target.Status, entities.TargetStatusCompleted)
+	}
+
+	mission := store.missions[1]
+	if mission.Status != entities.MissionStatusCompleted || !mission.IsCompleted {
+		t.Errorf("mission status = %q (is_completed=%v), want completed", mission.Status, mission.IsCompleted)
+	}
+	if mission.CatID != nil {
+		t.Errorf("mission cat_id = %d, want nil", *mission.CatID)
+	}
+	if mission.CompletedAt == nil {
+		t.Error("mission completed_at was not set")
+	}
+
+	if cat := store.cats[7]; cat.MissionID != nil {
+		t.Errorf("cat mission_id = %d, want nil", *cat.MissionID)
+	}
+
+	if assignment := store.assignments[0]; assignment.EndedAt == nil || assignment.EndReason == nil || *assignment.EndReason != entities.AssignmentEndCompleted {
+		t.Errorf("assignment = %+v, want it closed as completed", assignment)
+	}
+
+	names := publisher.names()
+	if len(names) != 2 || names[0] != events.NameTargetStatusChanged || names[1] != events.NameMissionCompleted {
+		t.Errorf("published events = %v, want [%s %s]", names, events.NameTargetStatusChanged, events.NameMissionCompleted)
+	}
+	if len(store.outbox) != 2 || store.outbox[0] != names[0] || store.outbox[1] != names[1] {
+		t.Errorf("outbox = %v, want the published events %v", store.outbox, names)
+	}
+
+	if len(store.audit) != 2 {
+		t.Fatalf("audit log = %+v, want the status change and the completion", store.audit)
+	}
+	statusEntry, completionEntry := store.audit[0], store.audit[1]
+	if statusEntry.Action != entities.AuditUpdateStatus || statusEntry.EntityType != entities.AuditEntityTarget || statusEntry.EntityID != 2 || statusEntry.ActorRole != auth.RoleCat {
+		t.Errorf("first audit entry = %+v, want target 2 update_status by a cat", statusEntry)
+	}
+	if !strings.Contains(*statusEntry.Before, `"status":"in_progress"`) || !strings.Contains(*statusEntry.After, `"status":"completed"`) {
+		t.Errorf("status entry before = %s, after = %s, want in_progress then completed", *statusEntry.Before, *statusEntry.After)
+	}
+	if completionEntry.Action != entities.AuditComplete || completionEntry.EntityType != entities.AuditEntityMission || completionEntry.EntityID != 1 {
+		t.Errorf("second audit entry = %+v, want mission 1 complete", completionEntry)
+	}
+	if !strings.Contains(*completionEntry.Before, `"status":"active"`) || !strings.Contains(*completionEntry.After, `"status":"completed"`) {
+		t.Errorf("completion entry before = %s, after = %s, want active then completed", *completionEntry.Before, *completionEntry.After)
+	}
+}
+
+func TestUpdateTargetStatusRollsBackWhenCatReleaseFails(t *testing.T) {
+	store := newActiveMissionStore()
+	store.failCatUnassign = true
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.UpdateTargetStatus(catContext(7), 7, 2, "completed", nil); err == nil {
+		t.Fatal("UpdateTargetStatus succeeded, want error when the cat cannot be released")
+	}
+
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestUpdateTargetStatusRollsBackWhenMissionCompletionFails(t *testing.T) {
+	store := newActiveMissionStore()
+	store.failMissionUpdate = true
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.UpdateTargetStatus(catContext(7), 7, 2, "completed", nil); err == nil {
+		t.Fatal("UpdateTargetStatus succeeded, want error when the mission cannot be completed")
+	}
+
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestUpdateTargetStatusKeepsMissionActiveWhileTargetsRemain(t *testing.T) {
+	store := newActiveMissionStore()
+	store.targets[2] = entities.Target{ID: 2, MissionID: 1, Name: "Captain Aquarius", Status: entities.TargetStatusInit, Version: 1}
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.UpdateTargetStatus(catContext(7), 7, 2, "in_progress", nil); err != nil {
+		t.Fatalf("UpdateTargetStatus returned error: %v", err)
+	}
+
+	if mission := store.missions[1]; mission.Status != entities.MissionStatusActive || mission.CatID == nil {
+		t.Errorf("mission status = %q, want active with its cat", mission.Status)
+	}
+	if cat := store.cats[7]; cat.MissionID == nil {
+		t.Error("cat was released from a mission that is still active")
+	}
+
+	if names := publisher.names(); len(names) != 1 || names[0] != events.NameTargetStatusChanged {
+		t.Errorf("published events = %v, want [%s]", names, events.NameTargetStatusChanged)
+	}
+}
+
+func TestUpdateTargetStatusRejectsStaleVersion(t *testing.T) {
+	store := newActiveMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	staleVersion := int32(0)
+	_, err := service.UpdateTargetStatus(catContext(7), 7, 2, "completed", &staleVersion)
+	if !errors.Is(err, entities.ErrVersionConflict) {
+		t.Fatalf("UpdateTargetStatus error = %v, want ErrVersionConflict", err)
+	}
+
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestUpdateTargetStatusRejectsAnotherCat(t *testing.T) {
+	store := newActiveMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	_, err := service.UpdateTargetStatus(catContext(8), 7, 2, "completed", nil)
+	if !errors.Is(err, entities.ErrNotThisCat) {
+		t.Fatalf("UpdateTargetStatus error = %v, want ErrNotThisCat", err)
+	}
+
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestUpdateTargetNotesChecksTheLockedMission(t *testing.T) {
+	store := newActiveMissionStore()
+	store.cats[8] = entities.SpyCat{ID: 8, Name: "Felix", Version: 1}
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.ReassignMission(directorContext(), 1, 8, nil); err != nil {
+		t.Fatalf("ReassignMission returned error: %v", err)
+	}
+	audited := len(store.audit)
+
+	version := int32(1)
+	if _, err := service.UpdateTargetNotes(catContext(7), 7, 2, "still on it", &version); !errors.Is(err, entities.ErrNotCatsTarget) {
+		t.Fatalf("UpdateTargetNotes by the outgoing cat: error = %v, want ErrNotCatsTarget", err)
+	}
+	if target := store.targets[2]; target.Notes != nil || target.Version != 1 {
+		t.Errorf("target = %+v, want it untouched", target)
+	}
+	if len(store.audit) != audited || len(publisher.names()) != 1 {
+		t.Errorf("audit log = %+v, published = %v, want only the reassignment", store.audit, publisher.names())
+	}
+
+	target, err := service.UpdateTargetNotes(catContext(8), 8, 2, "taking over", &version)
+	if err != nil {
+		t.Fatalf("UpdateTargetNotes by the incoming cat returned error: %v", err)
+	}
+	if target.Notes == nil || *target.Notes != "taking over" || target.Version != 2 {
+		t.Errorf("target = %+v, want the new notes at version 2", target)
+	}
+}
+
+func TestAddTargetToMissionChecksTheLockedMission(t *testing.T) {
+	store := newActiveMissionStore()
+	service, _ := newTestMissionService(store)
+
+	target, err := service.AddTargetToMission(directorContext(), 1, dto.AddTargetRequest{Name: "Agent Orange", Country: "Peru"})
+	if err != nil {
+		t.Fatalf("AddTargetToMission returned error: %v", err)
+	}
+	if target.MissionID != 1 || len(store.targets) != 3 {
+		t.Errorf("target = %+v, targets = %d, want a third target on mission 1", target, len(store.targets))
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*fakeStore)
+		target  string
+		wantErr error
+	}{
+		{name: "full mission", target: "Baron Salmon", wantErr: entities.ErrTooManyTargets},
+		{name: "duplicate name", target: "Agent Orange", mutate: func(s *fakeStore) { delete(s.targets, 1) }, wantErr: entities.ErrDuplicateTargetName},
+		{name: "finished mission", target: "Baron Salmon", mutate: func(s *fakeStore) {
+			delete(s.targets, 1)
+			mission := s.missions[1]
+			mission.Status = entities.MissionStatusCompleted
+			s.missions[1] = mission
+		}, wantErr: entities.ErrMissionFinished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mutate != nil {
+				tt.mutate(store)
+			}
+			before := len(store.targets)
+			audited := len(store.audit)
+
+			_, err := service.AddTargetToMission(directorContext(), 1, dto.AddTargetRequest{Name: tt.target, Country: "Peru"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddTargetToMission error = %v, want %v", err, tt.wantErr)
+			}
+			if len(store.targets) != before || len(store.audit) != audited {
+				t.Errorf("targets = %d, audit entries = %d, want %d and %d", len(store.targets), len(store.audit), before, audited)
+			}
+		})
+	}
+}
+
+func TestDeleteTargetFromMissionChecksTheLockedMission(t *testing.T) {
+	store := newActiveMissionStore()
+	store.targets[1] = entities.Target{ID: 1, MissionID: 1, Name: "Dr. Fisherman", Status: entities.TargetStatusInit, Version: 1}
+	store.targets[2] = entities.Target{ID: 2, MissionID: 1, Name: "Captain Aquarius", Status: entities.TargetStatusInit, Version: 1}
+	store.targets[3] = entities.Target{ID: 3, MissionID: 2, Name: "Baron Salmon", Status: entities.TargetStatusInit, Version: 1}
+	service, _ := newTestMissionService(store)
+
+	version := int32(1)
+	if err := service.DeleteTargetFromMission(directorContext(), 1, 1, &version); err != nil {
+		t.Fatalf("DeleteTargetFromMission returned error: %v", err)
+	}
+	if _, ok := store.targets[1]; ok {
+		t.Error("target 1 still exists")
+	}
+
+	staleVersion := int32(0)
+	tests := []struct {
+		name     string
+		mutate   func(*fakeStore)
+		targetID int32
+		version  *int32
+		wantErr  error
+	}{
+		{name: "last target", targetID: 2, wantErr: entities.ErrTooFewTargets},
+		{name: "target of another mission", targetID: 3, wantErr: entities.ErrTargetNotFound},
+		{name: "stale version", targetID: 2, version: &staleVersion, wantErr: entities.ErrVersionConflict},
+		{name: "started target", targetID: 2, mutate: func(s *fakeStore) {
+			s.targets[4] = entities.Target{ID: 4, MissionID: 1, Name: "Agent Orange", Status: entities.TargetStatusInit, Version: 1}
+			target := s.targets[2]
+			target.Status = entities.TargetStatusInProgress
+			s.targets[2] = target
+		}, wantErr: entities.ErrTargetNotDeletable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mutate != nil {
+				tt.mutate(store)
+			}
+			before := len(store.targets)
+			audited := len(store.audit)
+
+			err := service.DeleteTargetFromMission(directorContext(), 1, tt.targetID, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteTargetFromMission error = %v, want %v", err, tt.wantErr)
+			}
+			if len(store.targets) != before || len(store.audit) != audited {
+				t.Errorf("targets = %d, audit entries = %d, want %d and %d", len(store.targets), len(store.audit), before, audited)
+			}
+		})
+	}
+}
+
+func TestDeleteMissionRemovesCompletedMissionWithItsHistory(t *testing.T) {
+	store := newActiveMissionStore()
+	store.handovers = []entities.MissionHandover{
+		{ID: 1, MissionID: 1, FromCatID: 6, ToCatID: 7, HandedOverAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	service, _ := newTestMissionService(store)
+
+	if _, err := service.UpdateTargetStatus(catContext(7), 7, 2, "completed", nil); err != nil {
+		t.Fatalf("UpdateTargetStatus returned error: %v", err)
+	}
+	version := store.missions[1].Version
+	if err := service.DeleteMission(directorContext(), 1, &version); err != nil {
+		t.Fatalf("DeleteMission returned error: %v", err)
+	}
+
+	if _, ok := store.missions[1]; ok {
+		t.Error("mission still exists")
+	}
+	if len(store.targets) != 0 {
+		t.Errorf("targets = %+v, want them deleted with the mission", store.targets)
+	}
+	if len(store.assignments) != 0 || len(store.handovers) != 0 {
+		t.Errorf("assignments = %+v, handovers = %+v, want them deleted with the mission", store.assignments, store.handovers)
+	}
+	if last := store.audit[len(store.audit)-1]; last.Action != entities.AuditDelete || last.EntityType != entities.AuditEntityMission || last.EntityID != 1 {
+		t.Errorf("last audit entry = %+v, want mission 1 delete", last)
+	}
+}
+
+func TestDeleteMissionKeepsHistoryOfStaffedMission(t *testing.T) {
+	store := newActiveMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	if err := service.DeleteMission(directorContext(), 1, nil); !errors.Is(err, entities.ErrMissionStaffed) {
+		t.Fatalf("DeleteMission error = %v, want ErrMissionStaffed", err)
+	}
+
+	if len(store.assignments) != 1 || len(store.targets) != 2 {
+		t.Errorf("assignments = %+v, targets = %+v, want them kept", store.assignments, store.targets)
+	}
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+// newAssignableMissionStore adds draft mission 3, finished mission 4 and free
+// cat 8 to the active mission store.
+func newAssignableMissionStore() *fakeStore {
+	store := newActiveMissionStore()
+	store.missions[3] = entities.Mission{ID: 3, Name: "Operation Sardine", Status: entities.MissionStatusDraft, Version: 1}
+	store.missions[4] = entities.Mission{ID: 4, Name: "Operation Tuna", Status: entities.MissionStatusCompleted, IsCompleted: true, Version: 1}
+	store.cats[8] = entities.SpyCat{ID: 8, Name: "Felix", Version: 1}
+	return store
+}
+
+func TestAssignCatToMissionLocksMissionThenCat(t *testing.T) {
+	store := newAssignableMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	mission, err := service.AssignCatToMission(directorContext(), 3, 8)
+	if err != nil {
+		t.Fatalf("AssignCatToMission returned error: %v", err)
+	}
+
+	if want := []string{"mission 3", "cat 8"}; !reflect.DeepEqual(store.locks, want) {
+		t.Errorf("locked rows = %v, want %v", store.locks, want)
+	}
+	if mission.Status != string(entities.MissionStatusAssigned) || mission.CatID == nil || *mission.CatID != 8 {
+		t.Errorf("mission = %+v, want assigned to cat 8", mission)
+	}
+	if cat := store.cats[8]; cat.MissionID == nil || *cat.MissionID != 3 {
+		t.Error("cat was not assigned to the mission")
+	}
+	if len(store.assignments) != 2 || store.assignments[1].MissionID != 3 || store.assignments[1].CatID != 8 {
+		t.Errorf("assignments = %+v, want an open one for cat 8 on mission 3", store.assignments)
+	}
+	if names := publisher.names(); len(names) != 1 || names[0] != events.NameCatAssigned {
+		t.Errorf("published events = %v, want [%s]", names, events.NameCatAssigned)
+	}
+	if len(store.audit) != 1 || store.audit[0].Action != entities.AuditAssign || store.audit[0].EntityID != 3 {
+		t.Errorf("audit log = %+v, want mission 3 assign", store.audit)
+	}
+}
+
+func TestAssignCatToMissionRefusals(t *testing.T) {
+	tests := []struct {
+		name      string
+		missionID int32
+		catID     int32
+		wantLocks []string
+		wantErr   error
+	}{
+		{name: "unknown cat", missionID: 3, catID: 99, wantLocks: []string{"mission 3", "cat 99"}, wantErr: entities.ErrCatNotFound},
+		{name: "cat on another mission", missionID: 3, catID: 7, wantLocks: []string{"mission 3", "cat 7"}, wantErr: entities.ErrCatAlreadyAssigned},
+		{name: "finished mission", missionID: 4, catID: 8, wantLocks: []string{"mission 4", "cat 8"}, wantErr: entities.ErrMissionFinished},
+		{name: "staffed mission", missionID: 1, catID: 8, wantLocks: []string{"mission 1", "cat 8"}, wantErr: entities.ErrMissionStaffed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newAssignableMissionStore()
+			service, publisher := newTestMissionService(store)
+
+			if _, err := service.AssignCatToMission(directorContext(), tt.missionID, tt.catID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssignCatToMission error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(store.locks, tt.wantLocks) {
+				t.Errorf("locked rows = %v, want %v", store.locks, tt.wantLocks)
+			}
+			if mission := store.missions[tt.missionID]; mission.Version != 1 {
+				t.Errorf("mission version = %d, want 1", mission.Version)
+			}
+			if cat := store.cats[8]; cat.MissionID != nil || cat.Version != 1 {
+				t.Errorf("cat 8 = %+v, want it still free", cat)
+			}
+			if len(store.assignments) != 1 {
+				t.Errorf("assignments = %+v, want only the existing one", store.assignments)
+			}
+			assertActiveMissionUnchanged(t, store)
+			assertNothingPublished(t, store, publisher)
+			assertNothingAudited(t, store)
+		})
+	}
+}
+
+func TestAbortMissionFreezesTargetsAndReleasesCat(t *testing.T) {
+	store := newActiveMissionStore()
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.AbortMission(directorContext(), 1, "cover blown"); err != nil {
+		t.Fatalf("AbortMission returned error: %v", err)
+	}
+
+	mission := store.missions[1]
+	if mission.Status != entities.MissionStatusAborted || mission.AbortReason == nil || *mission.AbortReason != "cover blown" || mission.AbortedAt == nil {
+		t.Errorf("mission = %+v, want aborted for cover blown", mission)
+	}
+	if mission.CatID != nil {
+		t.Errorf("mission cat_id = %d, want nil", *mission.CatID)
+	}
+	if cat := store.cats[7]; cat.MissionID != nil {
+		t.Errorf("cat mission_id = %d, want nil", *cat.MissionID)
+	}
+
+	if target := store.targets[1]; target.Status != entities.TargetStatusCompleted {
+		t.Errorf("completed target status = %q, want it kept", target.Status)
+	}
+	if target := store.targets[2]; target.Status != entities.TargetStatusFrozen {
+		t.Errorf("in progress target status = %q, want frozen", target.Status)
+	}
+
+	if assignment := store.assignments[0]; assignment.EndedAt == nil || assignment.EndReason == nil || *assignment.EndReason != entities.AssignmentEndAborted {
+		t.Errorf("assignment = %+v, want it closed as aborted", assignment)
+	}
+
+	names := publisher.names()
+	if len(names) != 2 || names[0] != events.NameMissionAborted || names[1] != events.NameTargetStatusChanged {
+		t.Errorf("published events = %v, want [%s %s]", names, events.NameMissionAborted, events.NameTargetStatusChanged)
+	}
+	if len(store.audit) != 1 || store.audit[0].Action != entities.AuditAbort || store.audit[0].EntityID != 1 {
+		t.Errorf("audit log = %+v, want mission 1 abort", store.audit)
+	}
+}
+
+func TestAbortMissionRefusesFinishedMission(t *testing.T) {
+	for _, status := range []entities.MissionStatus{entities.MissionStatusCompleted, entities.MissionStatusAborted} {
+		t.Run(string(status), func(t *testing.T) {
+			store := newActiveMissionStore()
+			mission := store.missions[1]
+			mission.Status = status
+			mission.CatID = nil
+			store.missions[1] = mission
+			service, publisher := newTestMissionService(store)
+
+			_, err := service.AbortMission(directorContext(), 1, "cover blown")
+			var transitionErr *entities.MissionTransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("AbortMission error = %v, want a MissionTransitionError", err)
+			}
+
+			if got := store.missions[1]; got.Status != status || got.Version != 1 || got.AbortReason != nil {
+				t.Errorf("mission = %+v, want it left %s", got, status)
+			}
+			if target := store.targets[2]; target.Status != entities.TargetStatusInProgress {
+				t.Errorf("target status = %q, want in_progress", target.Status)
+			}
+			assertNothingPublished(t, store, publisher)
+			assertNothingAudited(t, store)
+		})
+	}
+}
+
+func TestAbortMissionRollsBackWhenCatReleaseFails(t *testing.T) {
+	store := newActiveMissionStore()
+	store.failCatUnassign = true
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.AbortMission(directorContext(), 1, "cover blown"); err == nil {
+		t.Fatal("AbortMission succeeded, want error when the cat cannot be released")
+	}
+
+	if mission := store.missions[1]; mission.AbortReason != nil || mission.Version != 1 {
+		t.Errorf("mission = %+v, want it untouched", mission)
+	}
+	if assignment := store.assignments[0]; assignment.EndedAt != nil {
+		t.Errorf("assignment = %+v, want it still open", assignment)
+	}
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func TestMissionWritesRejectStaleVersion(t *testing.T) {
+	staleVersion := int32(0)
+	name := "Operation Catfish"
+
+	tests := []struct {
+		name  string
+		write func(MissionService) error
+	}{
+		{name: "update mission", write: func(service MissionService) error {
+			_, err := service.UpdateMission(directorContext(), 1, dto.UpdateMissionRequest{Name: &name}, &staleVersion)
+			return err
+		}},
+		{name: "delete mission", write: func(service MissionService) error {
+			return service.DeleteMission(directorContext(), 1, &staleVersion)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newActiveMissionStore()
+			service, publisher := newTestMissionService(store)
+
+			if err := tt.write(service); !errors.Is(err, entities.ErrVersionConflict) {
+				t.Fatalf("error = %v, want ErrVersionConflict", err)
+			}
+
+			if mission := store.missions[1]; mission.Name != "Operation Goldfish" || mission.Version != 1 {
+				t.Errorf("mission = %q v%d, want Operation Goldfish v1", mission.Name, mission.Version)
+			}
+			if len(store.targets) != 2 || len(store.assignments) != 1 {
+				t.Errorf("targets = %+v, assignments = %+v, want them kept", store.targets, store.assignments)
+			}
+			assertActiveMissionUnchanged(t, store)
+			assertNothingPublished(t, store, publisher)
+			assertNothingAudited(t, store)
+		})
+	}
+}
+
+func TestReassignMissionHandsOverToTheIncomingCat(t *testing.T) {
+	store := newActiveMissionStore()
+	store.cats[8] = entities.SpyCat{ID: 8, Name: "Felix", Version: 1}
+	service, publisher := newTestMissionService(store)
+
+	mission, err := service.ReassignMission(directorContext(), 1, 8, nil)
+	if err != nil {
+		t.Fatalf("ReassignMission returned error: %v", err)
+	}
+
+	if mission.CatID == nil || *mission.CatID != 8 {
+		t.Errorf("mission cat_id = %v, want 8", mission.CatID)
+	}
+	if cat := store.cats[7]; cat.MissionID != nil {
+		t.Errorf("outgoing cat mission_id = %d, want nil", *cat.MissionID)
+	}
+	if cat := store.cats[8]; cat.MissionID == nil || *cat.MissionID != 1 {
+		t.Error("incoming cat was not assigned to the mission")
+	}
+
+	if len(store.handovers) != 1 || store.handovers[0].FromCatID != 7 || store.handovers[0].ToCatID != 8 {
+		t.Errorf("handovers = %+v, want one from cat 7 to cat 8", store.handovers)
+	}
+	if len(store.assignments) != 2 || store.assignments[0].EndReason == nil || *store.assignments[0].EndReason != entities.AssignmentEndReassigned ||
+		store.assignments[1].CatID != 8 || store.assignments[1].EndedAt != nil {
+		t.Errorf("assignments = %+v, want cat 7 closed as reassigned and cat 8 open", store.assignments)
+	}
+
+	if names := publisher.names(); len(names) != 1 || names[0] != events.NameMissionReassigned {
+		t.Errorf("published events = %v, want [%s]", names, events.NameMissionReassigned)
+	}
+	if len(store.audit) != 1 || store.audit[0].Action != entities.AuditReassign || store.audit[0].EntityID != 1 {
+		t.Errorf("audit log = %+v, want mission 1 reassign", store.audit)
+	}
+}
+
+func TestReassignMissionRollsBackWhenHandoverFails(t *testing.T) {
+	store := newActiveMissionStore()
+	store.cats[8] = entities.SpyCat{ID: 8, Name: "Felix", Version: 1}
+	store.failHandover = true
+	service, publisher := newTestMissionService(store)
+
+	if _, err := service.ReassignMission(directorContext(), 1, 8, nil); err == nil {
+		t.Fatal("ReassignMission succeeded, want error when the handover cannot be recorded")
+	}
+
+	if mission := store.missions[1]; mission.Version != 1 {
+		t.Errorf("mission version = %d, want 1", mission.Version)
+	}
+	if cat := store.cats[8]; cat.MissionID != nil || cat.Version != 1 {
+		t.Errorf("incoming cat = %+v, want it still free", cat)
+	}
+	if len(store.handovers) != 0 {
+		t.Errorf("handovers = %+v, want none", store.handovers)
+	}
+	if len(store.assignments) != 1 || store.assignments[0].EndedAt != nil {
+		t.Errorf("assignments = %+v, want cat 7's still open", store.assignments)
+	}
+	assertActiveMissionUnchanged(t, store)
+	assertNothingPublished(t, store, publisher)
+	assertNothingAudited(t, store)
+}
+
+func assertActiveMissionUnchanged(t *testing.T, store *fakeStore) {
+	t.Helper()
+
+	if target := store.targets[2]; target.Status != entities.TargetStatusInProgress || target.Version != 1 {
+		t.Errorf("target = %q v%d, want in_progress v1", target.Status, target.Version)
+	}
+
+	mission := store.missions[1]
+	if mission.Status != entities.MissionStatusActive || mission.IsCompleted {
+		t.Errorf("mission status = %q (is_completed=%v), want active", mission.Status, mission.IsCompleted)
+	}
+	if mission.CatID == nil || *mission.CatID != 7 {
+		t.Error("mission lost its cat")
+	}
+
+	if cat := store.cats[7]; cat.MissionID == nil || *cat.MissionID != 1 {
+		t.Error("cat was released from its mission")
+	}
+}
+
+func assertNothingPublished(t *testing.T, store *fakeStore, publisher *recordingPublisher) {
+	t.Helper()
+
+	if names := publisher.names(); len(names) > 0 {
+		t.Errorf("published events = %v after a rolled back transaction, want none", names)
+	}
+	if len(store.outbox) > 0 {
+		t.Errorf("outbox = %v after a rolled back transaction, want empty", store.outbox)
+	}
+}
+
+func assertNothingAudited(t *testing.T, store *fakeStore) {
+	t.Helper()
+
+	if len(store.audit) > 0 {
+		t.Errorf("audit log = %+v after a rolled back transaction, want empty", store.audit)
+	}
+}
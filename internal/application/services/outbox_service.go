@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+// OutboxService lets directors inspect the outbox and queue events for
+// delivery again. Events are appended by the services that produce them.
+type OutboxService interface {
+	ListOutboxEvents(ctx context.Context, filter interfaces.OutboxFilter) ([]dto.OutboxEventResponse, error)
+	ReplayOutboxEvent(ctx context.Context, id int64) (*dto.OutboxEventResponse, error)
+}
+
+type outboxService struct {
+	db         database.TransactionManager
+	outboxRepo interfaces.OutboxRepository
+	auditRepo  interfaces.AuditRepository
+}
+
+func NewOutboxService(db database.TransactionManager, outboxRepo interfaces.OutboxRepository, auditRepo interfaces.AuditRepository) OutboxService {
+	return &outboxService{
+		db:         db,
+		outboxRepo: outboxRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+func (s *outboxService) ListOutboxEvents(ctx context.Context, filter interfaces.OutboxFilter) ([]dto.OutboxEventResponse, error) {
+	if err := authorize(ctx, auth.PermOutboxManage); err != nil {
+		return nil, err
+	}
+
+	outboxEvents, err := s.outboxRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OutboxEventResponse, len(outboxEvents))
+	for i, event := range outboxEvents {
+		responses[i] = dto.OutboxEventFromModel(event)
+	}
+	return responses, nil
+}
+
+func (s *outboxService) ReplayOutboxEvent(ctx context.Context, id int64) (*dto.OutboxEventResponse, error) {
+	if err := authorize(ctx, auth.PermOutboxManage); err != nil {
+		return nil, err
+	}
+
+	var response dto.OutboxEventResponse
+
+	err := s.db.RunTransaction(func(tx *gorm.DB) error {
+		event, err := s.outboxRepo.WithTx(tx).Replay(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		response = dto.OutboxEventFromModel(event)
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditReplay, entities.AuditEntityOutboxEvent, id, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+const maxSearchQueryLength = 200
+
+type SearchService interface {
+	Search(ctx context.Context, query interfaces.SearchQuery) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	searchRepo interfaces.SearchRepository
+}
+
+func NewSearchService(searchRepo interfaces.SearchRepository) SearchService {
+	return &searchService{
+		searchRepo: searchRepo,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, query interfaces.SearchQuery) (*dto.SearchResponse, error) {
+	if err := authorize(ctx, auth.PermMissionsRead); err != nil {
+		return nil, err
+	}
+
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return nil, fmt.Errorf("%w: q is required", entities.ErrInvalidSearchQuery)
+	}
+	if utf8.RuneCountInString(query.Text) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: q must be at most %d characters", entities.ErrInvalidSearchQuery, maxSearchQueryLength)
+	}
+
+	hits, err := s.searchRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.searchRepo.Count(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.SearchResultResponse, len(hits))
+	for i, hit := range hits {
+		results[i] = dto.SearchResultFromHit(hit)
+	}
+
+	return &dto.SearchResponse{
+		Query:   query.Text,
+		Results: results,
+		Total:   total,
+		Limit:   query.Limit,
+		Offset:  query.Offset,
+	}, nil
+}
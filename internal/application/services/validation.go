@@ -6,9 +6,18 @@ import (
 	"strings"
 	"time"
 
+	"spy-cat-agency/internal/domain/apperrors"
+	"spy-cat-agency/internal/domain/entities"
+
 	"github.com/labstack/echo/v4"
 )
 
+var (
+	ErrInvalidParameter = apperrors.Invalid("invalid_parameter", "invalid parameter")
+	ErrInvalidBody      = apperrors.Invalid("invalid_body", "invalid request body format: please check your JSON format and field types")
+	ErrValidationFailed = apperrors.Invalid("validation_failed", "validation failed")
+)
+
 type ValidationService struct{}
 
 func NewValidationService() *ValidationService {
@@ -17,16 +26,16 @@ func NewValidationService() *ValidationService {
 
 func (v *ValidationService) ValidateID(idStr, paramName string) (int32, error) {
 	if idStr == "" {
-		return 0, fmt.Errorf("missing %s parameter", paramName)
+		return 0, ErrInvalidParameter.WithDetail(fmt.Sprintf("missing %s parameter", paramName))
 	}
 
 	id, err := strconv.ParseInt(idStr, 10, 32)
 	if err != nil {
-		return 0, fmt.Errorf("invalid %s: must be a valid integer", paramName)
+		return 0, ErrInvalidParameter.WithDetail(fmt.Sprintf("invalid %s: must be a valid integer", paramName))
 	}
 
 	if id <= 0 {
-		return 0, fmt.Errorf("invalid %s: must be a positive integer", paramName)
+		return 0, ErrInvalidParameter.WithDetail(fmt.Sprintf("invalid %s: must be a positive integer", paramName))
 	}
 
 	return int32(id), nil
@@ -55,13 +64,13 @@ func (v *ValidationService) ValidatePaginationParams(c echo.Context) (limit, off
 	limitStr := c.QueryParam("limit")
 	if limitStr != "" {
 		if l, err := strconv.ParseInt(limitStr, 10, 32); err != nil {
-			return 0, 0, fmt.Errorf("invalid limit parameter: must be a valid integer")
+			return 0, 0, ErrInvalidParameter.WithDetail("invalid limit parameter: must be a valid integer")
 		} else {
 			if l <= 0 {
-				return 0, 0, fmt.Errorf("invalid limit parameter: must be a positive integer")
+				return 0, 0, ErrInvalidParameter.WithDetail("invalid limit parameter: must be a positive integer")
 			}
 			if l > 100 {
-				return 0, 0, fmt.Errorf("invalid limit parameter: maximum allowed is 100")
+				return 0, 0, ErrInvalidParameter.WithDetail("invalid limit parameter: maximum allowed is 100")
 			}
 			limit = int32(l)
 		}
@@ -70,10 +79,10 @@ func (v *ValidationService) ValidatePaginationParams(c echo.Context) (limit, off
 	offsetStr := c.QueryParam("offset")
 	if offsetStr != "" {
 		if o, err := strconv.ParseInt(offsetStr, 10, 32); err != nil {
-			return 0, 0, fmt.Errorf("invalid offset parameter: must be a valid integer")
+			return 0, 0, ErrInvalidParameter.WithDetail("invalid offset parameter: must be a valid integer")
 		} else {
 			if o < 0 {
-				return 0, 0, fmt.Errorf("invalid offset parameter: cannot be negative")
+				return 0, 0, ErrInvalidParameter.WithDetail("invalid offset parameter: cannot be negative")
 			}
 			offset = int32(o)
 		}
@@ -84,50 +93,33 @@ func (v *ValidationService) ValidatePaginationParams(c echo.Context) (limit, off
 
 func (v *ValidationService) ValidateJSONBinding(c echo.Context, req interface{}, entityName string) error {
 	if err := c.Bind(req); err != nil {
-		return fmt.Errorf("invalid request body format for %s: please check your JSON format and field types", entityName)
+		return ErrInvalidBody.WithDetail(fmt.Sprintf("invalid request body format for %s: please check your JSON format and field types", entityName))
 	}
 	return nil
 }
 
 func (v *ValidationService) ValidateEchoStruct(c echo.Context, req interface{}, entityName string) error {
 	if err := c.Validate(req); err != nil {
-		return fmt.Errorf("validation failed for %s: %s", entityName, err.Error())
+		return ErrValidationFailed.WithDetail(err.Error())
 	}
 	return nil
 }
 
 func (v *ValidationService) ValidateTargetStatus(status string) error {
-	validStatuses := []string{"init", "in_progress", "completed"}
-	status = strings.ToLower(strings.TrimSpace(status))
-
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return nil
-		}
+	if _, err := entities.ParseTargetStatus(status); err != nil {
+		validStatuses := []entities.TargetStatus{entities.TargetStatusInit, entities.TargetStatusInProgress, entities.TargetStatusCompleted}
+		return entities.ErrUnknownTargetStatus.WithDetail(fmt.Sprintf("invalid target status: must be one of %v", validStatuses))
 	}
-
-	return fmt.Errorf("invalid target status: must be one of %v", validStatuses)
-}
-
-func (v *ValidationService) CreateErrorResponse(message, details string) map[string]interface{} {
-	response := map[string]interface{}{
-		"error": message,
-	}
-
-	if details != "" {
-		response["details"] = details
-	}
-
-	return response
+	return nil
 }
 
 func validateMissionName(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("mission name is required")
+		return entities.ErrInvalidMission.WithDetail("mission name is required")
 	}
 	if len(name) > 100 {
-		return fmt.Errorf("mission name must be less than 100 characters")
+		return entities.ErrInvalidMission.WithDetail("mission name must be less than 100 characters")
 	}
 	return nil
 }
@@ -135,10 +127,10 @@ func validateMissionName(name string) error {
 func validateMissionDescription(description string) error {
 	description = strings.TrimSpace(description)
 	if description == "" {
-		return fmt.Errorf("mission description is required")
+		return entities.ErrInvalidMission.WithDetail("mission description is required")
 	}
 	if len(description) > 500 {
-		return fmt.Errorf("mission description must be less than 500 characters")
+		return entities.ErrInvalidMission.WithDetail("mission description must be less than 500 characters")
 	}
 	return nil
 }
@@ -148,7 +140,7 @@ func validateMissionDates(startDate, endDate time.Time) error {
 		return nil
 	}
 	if endDate.Before(startDate) {
-		return fmt.Errorf("mission end date must be after start date")
+		return entities.ErrInvalidMission.WithDetail("mission end date must be after start date")
 	}
 	return nil
 }
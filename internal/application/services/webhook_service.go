@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/application/dto"
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+	"spy-cat-agency/internal/infrastructure/database"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]*dto.WebhookResponse, error)
+	GetWebhook(ctx context.Context, id int32) (*dto.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id int32, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id int32) error
+	ListDeliveries(ctx context.Context, id int32, limit, offset int32) ([]dto.WebhookDeliveryResponse, error)
+}
+
+type webhookService struct {
+	db          database.TransactionManager
+	webhookRepo interfaces.WebhookRepository
+	auditRepo   interfaces.AuditRepository
+}
+
+func NewWebhookService(db database.TransactionManager, webhookRepo interfaces.WebhookRepository, auditRepo interfaces.AuditRepository) WebhookService {
+	return &webhookService{
+		db:          db,
+		webhookRepo: webhookRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// CreateWebhook generates a signing secret unless one is supplied. The secret
+// is only part of this response.
+func (s *webhookService) CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := entities.NewWebhookSubscription(req.URL, secret, req.Events, req.Description)
+
+	err := s.db.RunTransaction(func(tx *gorm.DB) error {
+		if err := s.webhookRepo.WithTx(tx).CreateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditCreate, entities.AuditEntityWebhook, int64(subscription.ID), nil, dto.WebhookFromModel(subscription))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.WebhookFromModel(subscription)
+	response.Secret = secret
+	return response, nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]*dto.WebhookResponse, error) {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = dto.WebhookFromModel(subscription)
+	}
+	return responses, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id int32) (*dto.WebhookResponse, error) {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.WebhookFromModel(subscription), nil
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, id int32, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := dto.WebhookFromModel(subscription)
+
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *req.URL
+	}
+
+	if req.Events != nil {
+		if err := validateWebhookEvents(*req.Events); err != nil {
+			return nil, err
+		}
+		subscription.SetEvents(*req.Events)
+	}
+
+	if req.Description != nil {
+		subscription.Description = req.Description
+	}
+
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	subscription.UpdatedAt = time.Now()
+	response := dto.WebhookFromModel(subscription)
+
+	err = s.db.RunTransaction(func(tx *gorm.DB) error {
+		if err := s.webhookRepo.WithTx(tx).UpdateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditUpdate, entities.AuditEntityWebhook, int64(id), before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int32) error {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return err
+	}
+
+	return s.db.RunTransaction(func(tx *gorm.DB) error {
+		webhookRepo := s.webhookRepo.WithTx(tx)
+		subscription, err := webhookRepo.GetSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := webhookRepo.DeleteSubscription(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, tx, entities.AuditDelete, entities.AuditEntityWebhook, int64(id), dto.WebhookFromModel(subscription), nil)
+	})
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, id int32, limit, offset int32) ([]dto.WebhookDeliveryResponse, error) {
+	if err := authorize(ctx, auth.PermWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.webhookRepo.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = dto.WebhookDeliveryFromModel(delivery)
+	}
+	return responses, nil
+}
+
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", entities.ErrInvalidWebhook)
+	}
+	return nil
+}
+
+func validateWebhookEvents(names []string) error {
+	for _, name := range names {
+		if name != entities.WebhookAllEvents && !events.IsKnownName(name) {
+			return fmt.Errorf("%w: unknown event '%s'", entities.ErrInvalidWebhook, name)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,123 @@
+// Package apperrors classifies the errors of the domain and service layers.
+// Every coded error has a Kind, which decides how it is reported, and a stable
+// Code that API clients can switch on instead of parsing messages.
+package apperrors
+
+import "errors"
+
+type Kind string
+
+const (
+	KindInvalid            Kind = "invalid"
+	KindUnauthenticated    Kind = "unauthenticated"
+	KindForbidden          Kind = "forbidden"
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindInvalidTransition  Kind = "invalid_transition"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindLimitExceeded      Kind = "limit_exceeded"
+	KindRateLimited        Kind = "rate_limited"
+	KindUnavailable        Kind = "unavailable"
+	KindInternal           Kind = "internal"
+)
+
+// Coded is implemented by every error that carries a kind and a code, so
+// typed errors such as status transition errors can take part as well.
+type Coded interface {
+	error
+	Kind() Kind
+	Code() string
+}
+
+// Error is a coded error. Package-level values serve as sentinels; WithDetail
+// derives an error with a more specific message that still matches its
+// sentinel with errors.Is.
+type Error struct {
+	kind    Kind
+	code    string
+	message string
+	parent  *Error
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{kind: kind, code: code, message: message}
+}
+
+func Invalid(code, message string) *Error {
+	return New(KindInvalid, code, message)
+}
+
+func Unauthenticated(code, message string) *Error {
+	return New(KindUnauthenticated, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+func LimitExceeded(code, message string) *Error {
+	return New(KindLimitExceeded, code, message)
+}
+
+func RateLimited(code, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+func Unavailable(code, message string) *Error {
+	return New(KindUnavailable, code, message)
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Unwrap exposes the sentinel a detailed error was derived from.
+func (e *Error) Unwrap() error {
+	if e.parent == nil {
+		return nil
+	}
+	return e.parent
+}
+
+// WithDetail returns an error of the same kind and code whose message is
+// detail.
+func (e *Error) WithDetail(detail string) *Error {
+	return &Error{kind: e.kind, code: e.code, message: detail, parent: e}
+}
+
+// As finds the first coded error in err's chain.
+func As(err error) (Coded, bool) {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded, true
+	}
+	return nil, false
+}
+
+// KindOf reports the kind of err, or KindInternal for uncoded errors.
+func KindOf(err error) Kind {
+	if coded, ok := As(err); ok {
+		return coded.Kind()
+	}
+	return KindInternal
+}
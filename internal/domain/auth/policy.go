@@ -0,0 +1,97 @@
+package auth
+
+// Role is what a login may do. Directors, handlers and finance are agency
+// staff; cats only reach their own mission.
+type Role string
+
+const (
+	RoleDirector Role = "director"
+	RoleHandler  Role = "handler"
+	RoleFinance  Role = "finance"
+	RoleCat      Role = "cat"
+	// RoleService is the role of API key callers, whose permissions are the
+	// scopes of their key rather than a fixed set.
+	RoleService Role = "service"
+)
+
+// StaffRoles lists the roles of agency staff.
+var StaffRoles = []Role{RoleDirector, RoleHandler, RoleFinance}
+
+// Permission names one operation of the API. Routes and services both check
+// them, so a caller that reaches a service some other way is still held to
+// the same policy.
+type Permission string
+
+const (
+	PermCatsRead        Permission = "cats:read"
+	PermCatsWrite       Permission = "cats:write"
+	PermCatsSalary      Permission = "cats:salary"
+	PermCatsDelete      Permission = "cats:delete"
+	PermMissionsRead    Permission = "missions:read"
+	PermMissionsWrite   Permission = "missions:write"
+	PermMissionsAssign  Permission = "missions:assign"
+	PermMissionsDelete  Permission = "missions:delete"
+	PermStaffManage     Permission = "staff:manage"
+	PermAPIKeysManage   Permission = "api_keys:manage"
+	PermAuditRead       Permission = "audit:read"
+	PermOutboxManage    Permission = "outbox:manage"
+	PermWebhooksManage  Permission = "webhooks:manage"
+	PermMetricsRead     Permission = "metrics:read"
+	PermOwnMissionRead  Permission = "own_mission:read"
+	PermOwnTargetsWrite Permission = "own_targets:write"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleDirector: {
+		PermCatsRead, PermCatsWrite, PermCatsSalary, PermCatsDelete,
+		PermMissionsRead, PermMissionsWrite, PermMissionsAssign, PermMissionsDelete,
+		PermStaffManage, PermAPIKeysManage, PermAuditRead, PermOutboxManage, PermWebhooksManage,
+		PermMetricsRead,
+	},
+	RoleHandler: {
+		PermCatsRead, PermCatsWrite,
+		PermMissionsRead, PermMissionsWrite, PermMissionsAssign,
+	},
+	RoleFinance: {
+		PermCatsRead, PermCatsSalary,
+		PermMissionsRead,
+	},
+	RoleCat: {
+		PermOwnMissionRead, PermOwnTargetsWrite,
+	},
+}
+
+// APIKeyScopes are the permissions an API key can be given. Managing logins
+// and keys is left to people, and the field permissions belong to cats.
+var APIKeyScopes = []Permission{
+	PermCatsRead, PermCatsWrite, PermCatsSalary, PermCatsDelete,
+	PermMissionsRead, PermMissionsWrite, PermMissionsAssign, PermMissionsDelete,
+	PermAuditRead, PermOutboxManage, PermWebhooksManage, PermMetricsRead,
+}
+
+func (p Permission) IsAPIKeyScope() bool {
+	return containsPermission(APIKeyScopes, p)
+}
+
+// IsValid reports whether r is the role of a login.
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) IsStaff() bool {
+	return r.IsValid() && r != RoleCat
+}
+
+func (r Role) Can(permission Permission) bool {
+	return containsPermission(rolePermissions[r], permission)
+}
+
+func containsPermission(permissions []Permission, wanted Permission) bool {
+	for _, permission := range permissions {
+		if permission == wanted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// Package auth describes who is calling the API. Middleware resolves the
+// caller once per request and stores it on the request context, where handlers
+// and services can read it back.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Principal is an authenticated caller. CatID is set for spy cats only;
+// APIKeyID and Scopes for API key callers only.
+type Principal struct {
+	CredentialID int32
+	Username     string
+	Role         Role
+	CatID        *int32
+	APIKeyID     *int32
+	Scopes       []Permission
+}
+
+func (p *Principal) IsStaff() bool {
+	return p.Role.IsStaff()
+}
+
+// Can reports whether the principal's role, or for API keys its scopes, grant
+// the permission.
+func (p *Principal) Can(permission Permission) bool {
+	if p.Role == RoleService {
+		return containsPermission(p.Scopes, permission)
+	}
+	return p.Role.Can(permission)
+}
+
+// IsCat reports whether the caller is the spy cat with the given ID.
+func (p *Principal) IsCat(catID int32) bool {
+	return p.Role == RoleCat && p.CatID != nil && *p.CatID == catID
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the caller stored on ctx, if any.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// TokenIssuer signs access tokens for principals and resolves them back.
+type TokenIssuer interface {
+	Issue(principal *Principal) (token string, expiresAt time.Time, err error)
+	// Verify fails for tampered, malformed and expired tokens alike.
+	Verify(token string) (*Principal, error)
+}
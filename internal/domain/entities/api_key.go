@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/domain/auth"
+)
+
+// APIKey lets a service call the API without a person logging in. Only a hash
+// of the key is stored; the prefix is kept in the clear to find the key and to
+// tell keys apart in listings.
+type APIKey struct {
+	ID         int32      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	Prefix     string     `json:"prefix" gorm:"size:16;not null;uniqueIndex"`
+	KeyHash    string     `json:"-" gorm:"size:64;not null"`
+	ScopeList  string     `json:"-" gorm:"column:scopes;type:text;not null"`
+	CreatedBy  string     `json:"created_by" gorm:"size:100;not null"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func NewAPIKey(name, prefix, keyHash string, scopes []auth.Permission, createdBy string, expiresAt *time.Time) *APIKey {
+	key := &APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	}
+	key.SetScopes(scopes)
+	return key
+}
+
+func (k *APIKey) Scopes() []auth.Permission {
+	if k.ScopeList == "" {
+		return nil
+	}
+	names := strings.Split(k.ScopeList, ",")
+	scopes := make([]auth.Permission, len(names))
+	for i, name := range names {
+		scopes[i] = auth.Permission(name)
+	}
+	return scopes
+}
+
+func (k *APIKey) SetScopes(scopes []auth.Permission) {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	k.ScopeList = strings.Join(names, ",")
+}
+
+// IsActive reports whether the key may still be used at the given time.
+func (k *APIKey) IsActive(at time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || at.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) Principal() *auth.Principal {
+	id := k.ID
+	return &auth.Principal{
+		Username: k.Name,
+		Role:     auth.RoleService,
+		APIKeyID: &id,
+		Scopes:   k.Scopes(),
+	}
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/domain/auth"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityCat         AuditEntityType = "cat"
+	AuditEntityMission     AuditEntityType = "mission"
+	AuditEntityTarget      AuditEntityType = "target"
+	AuditEntityCredential  AuditEntityType = "credential"
+	AuditEntityAPIKey      AuditEntityType = "api_key"
+	AuditEntityWebhook     AuditEntityType = "webhook"
+	AuditEntityOutboxEvent AuditEntityType = "outbox_event"
+)
+
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityCat, AuditEntityMission, AuditEntityTarget,
+		AuditEntityCredential, AuditEntityAPIKey, AuditEntityWebhook, AuditEntityOutboxEvent:
+		return true
+	}
+	return false
+}
+
+type AuditAction string
+
+const (
+	AuditCreate       AuditAction = "create"
+	AuditUpdate       AuditAction = "update"
+	AuditUpdateSalary AuditAction = "update_salary"
+	AuditDelete       AuditAction = "delete"
+	AuditAssign       AuditAction = "assign"
+	AuditReassign     AuditAction = "reassign"
+	AuditAbort        AuditAction = "abort"
+	AuditComplete     AuditAction = "complete"
+	AuditUpdateStatus AuditAction = "update_status"
+	AuditUpdateNotes  AuditAction = "update_notes"
+	AuditRevoke       AuditAction = "revoke"
+	AuditReplay       AuditAction = "replay"
+)
+
+// AuditEntry records who changed an entity and how. It is written in the same
+// transaction as the change, so the log holds exactly the changes that
+// committed. Before is empty for creations and After for deletions.
+type AuditEntry struct {
+	ID         int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Actor      string          `json:"actor" gorm:"size:100;not null;index"`
+	ActorRole  auth.Role       `json:"actor_role" gorm:"size:20;not null"`
+	Action     AuditAction     `json:"action" gorm:"size:50;not null"`
+	EntityType AuditEntityType `json:"entity_type" gorm:"size:20;not null;index:idx_audit_entity,priority:1"`
+	EntityID   int64           `json:"entity_id" gorm:"not null;index:idx_audit_entity,priority:2"`
+	Before     *string         `json:"before,omitempty" gorm:"type:jsonb"`
+	After      *string         `json:"after,omitempty" gorm:"type:jsonb"`
+	CreatedAt  time.Time       `json:"created_at" gorm:"not null;index"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+func NewAuditEntry(actor *auth.Principal, action AuditAction, entityType AuditEntityType, entityID int64, before, after []byte, at time.Time) *AuditEntry {
+	return &AuditEntry{
+		Actor:      actor.Username,
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     jsonOrNil(before),
+		After:      jsonOrNil(after),
+		CreatedAt:  at,
+	}
+}
+
+func jsonOrNil(data []byte) *string {
+	if data == nil {
+		return nil
+	}
+	value := string(data)
+	return &value
+}
@@ -11,6 +11,7 @@ type SpyCat struct {
 	Breed             string    `gorm:"type:varchar(100);not null"`
 	Salary            float64   `gorm:"type:numeric(12,2);not null;check:salary >= 0"`
 	MissionID         *int32    `gorm:"type:integer;default:null;index"`
+	Version           int32     `gorm:"not null;default:1"`
 	CreatedAt         time.Time `gorm:"not null;default:now()"`
 	UpdatedAt         time.Time `gorm:"not null;default:now()"`
 }
@@ -32,3 +33,21 @@ func (c *SpyCat) UpdateSalary(newSalary float64) {
 	c.Salary = newSalary
 	c.UpdatedAt = time.Now()
 }
+
+// UpdateProfile applies a partial profile change; nil fields are left as is.
+func (c *SpyCat) UpdateProfile(name, breed *string, yearsOfExperience *int32) {
+	if name != nil {
+		c.Name = *name
+	}
+	if breed != nil {
+		c.Breed = *breed
+	}
+	if yearsOfExperience != nil {
+		c.YearsOfExperience = *yearsOfExperience
+	}
+	c.UpdatedAt = time.Now()
+}
+
+func (c *SpyCat) IsAvailable() bool {
+	return c.MissionID == nil
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/domain/auth"
+)
+
+// Credential is a login for an agency staff member or a spy cat. Cat logins
+// are tied to their cat and go away with it.
+type Credential struct {
+	ID           int32     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Username     string    `json:"username" gorm:"size:100;not null;uniqueIndex"`
+	PasswordHash string    `json:"-" gorm:"size:100;not null"`
+	Role         auth.Role `json:"role" gorm:"size:20;not null"`
+	CatID        *int32    `json:"cat_id,omitempty" gorm:"uniqueIndex"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Cat *SpyCat `json:"-" gorm:"foreignKey:CatID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (Credential) TableName() string {
+	return "credentials"
+}
+
+func NewStaffCredential(username, passwordHash string, role auth.Role) *Credential {
+	return &Credential{
+		Username:     username,
+		PasswordHash: passwordHash,
+		Role:         role,
+	}
+}
+
+func NewCatCredential(catID int32, username, passwordHash string) *Credential {
+	return &Credential{
+		Username:     username,
+		PasswordHash: passwordHash,
+		Role:         auth.RoleCat,
+		CatID:        &catID,
+	}
+}
+
+func (c *Credential) Principal() *auth.Principal {
+	return &auth.Principal{
+		CredentialID: c.ID,
+		Username:     c.Username,
+		Role:         c.Role,
+		CatID:        c.CatID,
+	}
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"fmt"
+
+	"spy-cat-agency/internal/domain/apperrors"
+	"spy-cat-agency/internal/domain/auth"
+)
+
+var (
+	ErrUnknownTargetStatus  = apperrors.Invalid("unknown_target_status", "unknown target status")
+	ErrUnknownMissionStatus = apperrors.Invalid("unknown_mission_status", "unknown mission status")
+	ErrInvalidMission       = apperrors.Invalid("invalid_mission", "invalid mission")
+	ErrInvalidTarget        = apperrors.Invalid("invalid_target", "invalid target")
+	ErrInvalidBreed         = apperrors.Invalid("invalid_breed", "please use a valid cat breed from TheCatAPI; available breeds can be fetched from /api/v1/cats/breeds")
+	ErrFreezeNotAllowed     = apperrors.Invalid("freeze_not_allowed", "targets are only frozen when their mission is aborted")
+	ErrMissionNotFound      = apperrors.NotFound("mission_not_found", "mission not found")
+	ErrTargetNotFound       = apperrors.NotFound("target_not_found", "target not found in mission")
+	ErrCatNotFound          = apperrors.NotFound("cat_not_found", "cat not found")
+	ErrOutboxEventNotFound  = apperrors.NotFound("outbox_event_not_found", "outbox event not found")
+	ErrWebhookNotFound      = apperrors.NotFound("webhook_not_found", "webhook subscription not found")
+	ErrAPIKeyNotFound       = apperrors.NotFound("api_key_not_found", "API key not found")
+	ErrInvalidCredentials   = apperrors.Unauthenticated("invalid_credentials", "invalid username or password")
+	ErrAuthRequired         = apperrors.Unauthenticated("authentication_required", "authentication is required")
+	ErrInvalidToken         = apperrors.Unauthenticated("invalid_token", "invalid or expired access token")
+	ErrInvalidAPIKey        = apperrors.Unauthenticated("invalid_api_key", "invalid, expired or revoked API key")
+	ErrInvalidAPIKeyScope   = apperrors.Invalid("invalid_api_key_scope", "unknown API key scope")
+	ErrPermissionDenied     = apperrors.Forbidden("permission_denied", "your role does not allow this")
+	ErrNotThisCat           = apperrors.Forbidden("cat_mismatch", "spy cats can only act as themselves")
+	ErrNotCatsTarget        = apperrors.Forbidden("target_not_on_cat_mission", "target does not belong to the cat's mission")
+	ErrTargetFinal          = apperrors.Conflict("target_final", "target is final and cannot be modified")
+	ErrTargetNotDeletable   = apperrors.Conflict("target_not_deletable", "only targets in 'init' status can be deleted")
+	ErrDuplicateTargetName  = apperrors.Conflict("duplicate_target_name", "target names must be unique within a mission")
+	ErrMissionFinished      = apperrors.Conflict("mission_finished", "mission is already finished")
+	ErrMissionNotStaffed    = apperrors.Conflict("mission_not_staffed", "mission has no assigned cat")
+	ErrMissionStaffed       = apperrors.Conflict("mission_staffed", "mission already has an assigned cat")
+	ErrSameCatHandover      = apperrors.Conflict("same_cat_handover", "mission is already assigned to this cat")
+	ErrCatAlreadyAssigned   = apperrors.Conflict("cat_already_assigned", "cat is already assigned to a mission")
+	ErrUsernameTaken        = apperrors.Conflict("username_taken", "username is already taken")
+	ErrCatOnMission         = apperrors.Conflict("cat_on_mission", "cat is currently assigned to a mission")
+	ErrTooFewTargets        = apperrors.LimitExceeded("too_few_targets", fmt.Sprintf("mission must have at least %d target", MinTargetsRequired))
+	ErrTooManyTargets       = apperrors.LimitExceeded("too_many_targets", fmt.Sprintf("mission cannot have more than %d targets", MaxTargetsAllowed))
+	ErrVersionConflict      = apperrors.PreconditionFailed("version_conflict", "resource was modified by another request")
+	ErrInvalidWebhook       = apperrors.Invalid("invalid_webhook", "invalid webhook subscription")
+	ErrInvalidCursor        = apperrors.Invalid("invalid_cursor", "invalid or expired cursor")
+	ErrInvalidSearchQuery   = apperrors.Invalid("invalid_search_query", "invalid search query")
+	ErrImportRolledBack     = apperrors.Conflict("import_rolled_back", "not created because another row of the import failed")
+)
+
+// CheckVersion compares the version a client last saw with the stored one.
+// A nil expectation means the client did not ask for a precondition.
+func CheckVersion(expected *int32, actual int32) error {
+	if expected != nil && *expected != actual {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// Authorize checks that a caller holds the permission; a nil principal is an
+// anonymous caller.
+func Authorize(principal *auth.Principal, permission auth.Permission) error {
+	if principal == nil {
+		return ErrAuthRequired
+	}
+	if !principal.Can(permission) {
+		if principal.Role == auth.RoleService {
+			return ErrPermissionDenied.WithDetail(fmt.Sprintf("API key %q lacks scope %q", principal.Username, permission))
+		}
+		return ErrPermissionDenied.WithDetail(fmt.Sprintf("role %q lacks permission %q", principal.Role, permission))
+	}
+	return nil
+}
+
+// TargetTransitionError is returned when a target is asked to move to a status
+// that is not reachable from its current one.
+type TargetTransitionError struct {
+	From TargetStatus
+	To   TargetStatus
+}
+
+func (e *TargetTransitionError) Kind() apperrors.Kind {
+	return apperrors.KindInvalidTransition
+}
+
+func (e *TargetTransitionError) Code() string {
+	return "invalid_target_transition"
+}
+
+func (e *TargetTransitionError) Error() string {
+	if e.From.IsFinal() {
+		return "target status is final and cannot be changed"
+	}
+	return fmt.Sprintf("target status cannot change from '%s' to '%s'", e.From, e.To)
+}
+
+// MissionTransitionError is returned when a mission is asked to move to a
+// lifecycle status that is not reachable from its current one.
+type MissionTransitionError struct {
+	From MissionStatus
+	To   MissionStatus
+}
+
+func (e *MissionTransitionError) Kind() apperrors.Kind {
+	return apperrors.KindInvalidTransition
+}
+
+func (e *MissionTransitionError) Code() string {
+	return "invalid_mission_transition"
+}
+
+func (e *MissionTransitionError) Error() string {
+	return fmt.Sprintf("mission status cannot change from '%s' to '%s'", e.From, e.To)
+}
@@ -1,7 +1,8 @@
 package entities
 
 import (
-	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,20 +11,62 @@ const (
 	MaxTargetsAllowed  = 3
 )
 
+type MissionStatus string
+
+const (
+	MissionStatusDraft     MissionStatus = "draft"
+	MissionStatusAssigned  MissionStatus = "assigned"
+	MissionStatusActive    MissionStatus = "active"
+	MissionStatusCompleted MissionStatus = "completed"
+	MissionStatusAborted   MissionStatus = "aborted"
+)
+
+// missionTransitions lists the statuses reachable from each status.
+// Completed and aborted are terminal.
+var missionTransitions = map[MissionStatus][]MissionStatus{
+	MissionStatusDraft:     {MissionStatusAssigned, MissionStatusAborted},
+	MissionStatusAssigned:  {MissionStatusActive, MissionStatusAborted},
+	MissionStatusActive:    {MissionStatusCompleted, MissionStatusAborted},
+	MissionStatusCompleted: {},
+	MissionStatusAborted:   {},
+}
+
+func ParseMissionStatus(value string) (MissionStatus, error) {
+	status := MissionStatus(strings.ToLower(strings.TrimSpace(value)))
+	if !status.IsValid() {
+		return "", ErrUnknownMissionStatus
+	}
+	return status, nil
+}
+
+func (s MissionStatus) IsValid() bool {
+	_, ok := missionTransitions[s]
+	return ok
+}
+
+func (s MissionStatus) IsTerminal() bool {
+	return s.IsValid() && len(missionTransitions[s]) == 0
+}
+
 type Mission struct {
-	ID          int32      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string     `json:"name" gorm:"not null;size:100"`
-	Description string     `json:"description" gorm:"not null;size:500"`
-	StartDate   time.Time  `json:"start_date" gorm:"not null"`
-	EndDate     time.Time  `json:"end_date" gorm:"not null"`
-	CatID       *int32     `json:"cat_id" gorm:"index"`
-	IsCompleted bool       `json:"is_completed" gorm:"default:false"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID          int32         `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string        `json:"name" gorm:"not null;size:100"`
+	Description string        `json:"description" gorm:"not null;size:500"`
+	StartDate   time.Time     `json:"start_date" gorm:"not null"`
+	EndDate     time.Time     `json:"end_date" gorm:"not null"`
+	CatID       *int32        `json:"cat_id" gorm:"index"`
+	Status      MissionStatus `json:"status" gorm:"size:20;not null;default:'draft';index"`
+	IsCompleted bool          `json:"is_completed" gorm:"default:false"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	AbortReason *string       `json:"abort_reason,omitempty" gorm:"size:500"`
+	AbortedAt   *time.Time    `json:"aborted_at,omitempty"`
+	Version     int32         `json:"version" gorm:"not null;default:1"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
 
-	Cat     *SpyCat  `json:"cat,omitempty" gorm:"foreignKey:CatID;references:ID"`
-	Targets []Target `json:"targets,omitempty" gorm:"foreignKey:MissionID"`
+	Cat       *SpyCat           `json:"cat,omitempty" gorm:"foreignKey:CatID;references:ID"`
+	Targets   []Target          `json:"targets,omitempty" gorm:"foreignKey:MissionID"`
+	Handovers []MissionHandover `json:"handovers,omitempty" gorm:"foreignKey:MissionID"`
 }
 
 func (m *Mission) Validate() error {
@@ -32,11 +75,11 @@ func (m *Mission) Validate() error {
 	}
 
 	if m.Name == "" {
-		return errors.New("mission name is required")
+		return ErrInvalidMission.WithDetail("mission name is required")
 	}
 
 	if m.Description == "" {
-		return errors.New("mission description is required")
+		return ErrInvalidMission.WithDetail("mission description is required")
 	}
 
 	return nil
@@ -46,30 +89,30 @@ func (m *Mission) ValidateTargets() error {
 	targetCount := len(m.Targets)
 
 	if targetCount < MinTargetsRequired {
-		return errors.New("mission must have at least one target")
+		return ErrTooFewTargets
 	}
 
 	if targetCount > MaxTargetsAllowed {
-		return errors.New("mission cannot have more than 3 targets")
+		return ErrTooManyTargets
 	}
 
 	for i, target := range m.Targets {
 		if target.Name == "" {
-			return errors.New("target name is required")
+			return ErrInvalidTarget.WithDetail("target name is required")
 		}
 		if target.Country == "" {
-			return errors.New("target country is required")
+			return ErrInvalidTarget.WithDetail("target country is required")
 		}
 		if len(target.Name) > 100 {
-			return errors.New("target name cannot exceed 100 characters")
+			return ErrInvalidTarget.WithDetail("target name cannot exceed 100 characters")
 		}
 		if len(target.Country) > 100 {
-			return errors.New("target country cannot exceed 100 characters")
+			return ErrInvalidTarget.WithDetail("target country cannot exceed 100 characters")
 		}
 
 		for j := i + 1; j < len(m.Targets); j++ {
 			if m.Targets[j].Name == target.Name {
-				return errors.New("duplicate target names are not allowed in the same mission")
+				return ErrDuplicateTargetName
 			}
 		}
 	}
@@ -77,10 +120,48 @@ func (m *Mission) ValidateTargets() error {
 	return nil
 }
 
+func (m *Mission) Target(id int32) *Target {
+	for i := range m.Targets {
+		if m.Targets[i].ID == id {
+			return &m.Targets[i]
+		}
+	}
+	return nil
+}
+
+func (m *Mission) AllTargetsCompleted() bool {
+	for _, target := range m.Targets {
+		if !target.IsCompleted() {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Mission) CanAddTarget() bool {
 	return len(m.Targets) < MaxTargetsAllowed
 }
 
+// CheckNewTarget reports why a target with the given name cannot be added:
+// the mission is finished, already has the most targets, or has a target with
+// that name.
+func (m *Mission) CheckNewTarget(name string) error {
+	if m.IsFinished() {
+		return ErrMissionFinished
+	}
+
+	if !m.CanAddTarget() {
+		return ErrTooManyTargets
+	}
+
+	for _, target := range m.Targets {
+		if target.Name == name {
+			return ErrDuplicateTargetName.WithDetail(fmt.Sprintf("target with name '%s' already exists in this mission", name))
+		}
+	}
+	return nil
+}
+
 func (m *Mission) HasMinimumTargets() bool {
 	return len(m.Targets) >= MinTargetsRequired
 }
@@ -88,3 +169,188 @@ func (m *Mission) HasMinimumTargets() bool {
 func (Mission) TableName() string {
 	return "missions"
 }
+
+func (m *Mission) IsFinished() bool {
+	return m.Status.IsTerminal()
+}
+
+func (m *Mission) CanTransitionTo(next MissionStatus) bool {
+	for _, allowed := range missionTransitions[m.Status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the mission to the next lifecycle status. Setting the
+// current status again is a no-op unless the mission is already finished.
+func (m *Mission) TransitionTo(next MissionStatus) error {
+	if !next.IsValid() {
+		return ErrUnknownMissionStatus
+	}
+
+	if next == m.Status && !m.IsFinished() {
+		return nil
+	}
+
+	if !m.CanTransitionTo(next) {
+		return &MissionTransitionError{From: m.Status, To: next}
+	}
+
+	m.Status = next
+	return nil
+}
+
+// EditDetails changes the name, description and planned dates; nil fields are
+// left as is. Finished missions are part of the record and cannot be edited.
+func (m *Mission) EditDetails(name, description *string, startDate, endDate *time.Time) error {
+	if m.IsFinished() {
+		return ErrMissionFinished
+	}
+
+	if name != nil {
+		m.Name = *name
+	}
+	if description != nil {
+		m.Description = *description
+	}
+	if startDate != nil {
+		m.StartDate = *startDate
+	}
+	if endDate != nil {
+		m.EndDate = *endDate
+	}
+
+	return nil
+}
+
+// EditTarget changes a target's name, country and notes; nil fields are left as
+// is. Targets of finished missions and completed or frozen targets are frozen,
+// and the edited target list must still pass ValidateTargets.
+func (m *Mission) EditTarget(targetID int32, name, country, notes *string) (*Target, error) {
+	if m.IsFinished() {
+		return nil, ErrMissionFinished
+	}
+
+	target := m.Target(targetID)
+	if target == nil {
+		return nil, ErrTargetNotFound
+	}
+
+	if target.IsFinal() {
+		return nil, ErrTargetFinal
+	}
+
+	previous := *target
+	if name != nil {
+		target.Name = *name
+	}
+	if country != nil {
+		target.Country = *country
+	}
+	if notes != nil {
+		target.Notes = notes
+	}
+
+	if err := m.ValidateTargets(); err != nil {
+		*target = previous
+		return nil, err
+	}
+
+	target.UpdatedAt = time.Now()
+	return target, nil
+}
+
+func (m *Mission) AssignCat(catID int32, at time.Time) error {
+	if m.IsFinished() {
+		return ErrMissionFinished
+	}
+
+	if m.CatID != nil {
+		return ErrMissionStaffed
+	}
+
+	if err := m.TransitionTo(MissionStatusAssigned); err != nil {
+		return err
+	}
+
+	m.CatID = &catID
+	m.StartDate = at
+	return nil
+}
+
+func (m *Mission) Activate() error {
+	return m.TransitionTo(MissionStatusActive)
+}
+
+// Complete finishes the mission and releases its cat. Missions that were
+// assigned but never activated are activated on the way.
+func (m *Mission) Complete(at time.Time) error {
+	if m.Status == MissionStatusAssigned {
+		if err := m.Activate(); err != nil {
+			return err
+		}
+	}
+
+	if err := m.TransitionTo(MissionStatusCompleted); err != nil {
+		return err
+	}
+
+	m.IsCompleted = true
+	m.CompletedAt = &at
+	if m.EndDate.IsZero() {
+		m.EndDate = at
+	}
+	m.CatID = nil
+	m.Cat = nil
+	return nil
+}
+
+// Abort calls the mission off, freezing every target that is not completed yet
+// and releasing its cat.
+func (m *Mission) Abort(reason string, at time.Time) error {
+	if err := m.TransitionTo(MissionStatusAborted); err != nil {
+		return err
+	}
+
+	for i := range m.Targets {
+		if err := m.Targets[i].Freeze(); err != nil {
+			return err
+		}
+	}
+
+	m.AbortReason = &reason
+	m.AbortedAt = &at
+	m.CatID = nil
+	m.Cat = nil
+	return nil
+}
+
+// HandOver moves the mission from its current cat to another one, keeping all
+// target progress. The returned record describes who handed over to whom.
+func (m *Mission) HandOver(toCatID int32, reason *string, at time.Time) (*MissionHandover, error) {
+	if m.IsFinished() {
+		return nil, ErrMissionFinished
+	}
+
+	if m.CatID == nil {
+		return nil, ErrMissionNotStaffed
+	}
+
+	if *m.CatID == toCatID {
+		return nil, ErrSameCatHandover
+	}
+
+	handover := &MissionHandover{
+		MissionID:    m.ID,
+		FromCatID:    *m.CatID,
+		ToCatID:      toCatID,
+		Reason:       reason,
+		HandedOverAt: at,
+	}
+
+	m.CatID = &toCatID
+	m.Cat = nil
+	return handover, nil
+}
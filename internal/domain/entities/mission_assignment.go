@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+)
+
+type AssignmentEndReason string
+
+const (
+	AssignmentEndCompleted  AssignmentEndReason = "completed"
+	AssignmentEndAborted    AssignmentEndReason = "aborted"
+	AssignmentEndReassigned AssignmentEndReason = "reassigned"
+)
+
+// MissionAssignment records one stretch of a cat working on a mission. Missions
+// forget their cat once finished, so this is the only lasting link between a
+// cat and the missions it worked on.
+type MissionAssignment struct {
+	ID         int32                `json:"id" gorm:"primaryKey;autoIncrement"`
+	MissionID  int32                `json:"mission_id" gorm:"not null;index"`
+	CatID      int32                `json:"cat_id" gorm:"not null;index:idx_mission_assignments_cat_history,priority:1"`
+	AssignedAt time.Time            `json:"assigned_at" gorm:"not null;index:idx_mission_assignments_cat_history,priority:2"`
+	EndedAt    *time.Time           `json:"ended_at,omitempty"`
+	EndReason  *AssignmentEndReason `json:"end_reason,omitempty" gorm:"size:20"`
+	CreatedAt  time.Time            `json:"created_at" gorm:"autoCreateTime"`
+
+	Mission *Mission `json:"mission,omitempty" gorm:"foreignKey:MissionID;references:ID"`
+}
+
+func NewMissionAssignment(missionID, catID int32, at time.Time) *MissionAssignment {
+	return &MissionAssignment{
+		MissionID:  missionID,
+		CatID:      catID,
+		AssignedAt: at,
+	}
+}
+
+func (MissionAssignment) TableName() string {
+	return "mission_assignments"
+}
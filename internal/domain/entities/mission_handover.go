@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+)
+
+type MissionHandover struct {
+	ID           int32     `json:"id" gorm:"primaryKey;autoIncrement"`
+	MissionID    int32     `json:"mission_id" gorm:"not null;index"`
+	FromCatID    int32     `json:"from_cat_id" gorm:"not null;index"`
+	ToCatID      int32     `json:"to_cat_id" gorm:"not null;index"`
+	Reason       *string   `json:"reason,omitempty" gorm:"size:500"`
+	HandedOverAt time.Time `json:"handed_over_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (MissionHandover) TableName() string {
+	return "mission_handovers"
+}
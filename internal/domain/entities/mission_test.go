@@ -0,0 +1,256 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var missionStatuses = []MissionStatus{MissionStatusDraft, MissionStatusAssigned, MissionStatusActive, MissionStatusCompleted, MissionStatusAborted}
+
+func TestMissionTransitionTo(t *testing.T) {
+	legal := map[MissionStatus][]MissionStatus{
+		MissionStatusDraft:    {MissionStatusDraft, MissionStatusAssigned, MissionStatusAborted},
+		MissionStatusAssigned: {MissionStatusAssigned, MissionStatusActive, MissionStatusAborted},
+		MissionStatusActive:   {MissionStatusActive, MissionStatusCompleted, MissionStatusAborted},
+	}
+
+	for _, from := range missionStatuses {
+		for _, to := range missionStatuses {
+			allowed := false
+			for _, next := range legal[from] {
+				allowed = allowed || next == to
+			}
+
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				mission := &Mission{Status: from}
+				err := mission.TransitionTo(to)
+
+				if allowed {
+					if err != nil {
+						t.Fatalf("TransitionTo error = %v, want none", err)
+					}
+					if mission.Status != to {
+						t.Errorf("status = %q, want %q", mission.Status, to)
+					}
+					return
+				}
+
+				var transitionErr *MissionTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("TransitionTo error = %v, want a MissionTransitionError", err)
+				}
+				if transitionErr.From != from || transitionErr.To != to {
+					t.Errorf("error = %+v, want %s -> %s", transitionErr, from, to)
+				}
+				if mission.Status != from {
+					t.Errorf("status = %q after a refused transition, want %q", mission.Status, from)
+				}
+			})
+		}
+	}
+}
+
+func TestMissionTransitionToUnknownStatus(t *testing.T) {
+	mission := &Mission{Status: MissionStatusDraft}
+	if err := mission.TransitionTo("paused"); !errors.Is(err, ErrUnknownMissionStatus) {
+		t.Errorf("TransitionTo error = %v, want ErrUnknownMissionStatus", err)
+	}
+}
+
+func TestMissionAssignCat(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	staffedBy := int32(7)
+
+	tests := []struct {
+		name    string
+		mission Mission
+		wantErr error
+	}{
+		{name: "draft", mission: Mission{Status: MissionStatusDraft}},
+		{name: "staffed", mission: Mission{Status: MissionStatusAssigned, CatID: &staffedBy}, wantErr: ErrMissionStaffed},
+		{name: "completed", mission: Mission{Status: MissionStatusCompleted}, wantErr: ErrMissionFinished},
+		{name: "aborted", mission: Mission{Status: MissionStatusAborted}, wantErr: ErrMissionFinished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := tt.mission
+			err := mission.AssignCat(8, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssignCat error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if mission.Status != tt.mission.Status || mission.CatID != tt.mission.CatID {
+					t.Errorf("mission = %+v after a refused assignment, want it unchanged", mission)
+				}
+				return
+			}
+			if mission.Status != MissionStatusAssigned || mission.CatID == nil || *mission.CatID != 8 || !mission.StartDate.Equal(at) {
+				t.Errorf("mission = %+v, want assigned to cat 8 from %v", mission, at)
+			}
+		})
+	}
+}
+
+func TestMissionComplete(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	catID := int32(7)
+
+	tests := []struct {
+		from    MissionStatus
+		wantErr bool
+	}{
+		{from: MissionStatusDraft, wantErr: true},
+		// An assigned mission whose last target is completed without ever
+		// being started is activated on the way.
+		{from: MissionStatusAssigned},
+		{from: MissionStatusActive},
+		{from: MissionStatusCompleted, wantErr: true},
+		{from: MissionStatusAborted, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			mission := &Mission{Status: tt.from, CatID: &catID}
+			err := mission.Complete(at)
+
+			if tt.wantErr {
+				var transitionErr *MissionTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("Complete error = %v, want a MissionTransitionError", err)
+				}
+				if mission.Status != tt.from || mission.IsCompleted || mission.CatID == nil {
+					t.Errorf("mission = %+v after a refused completion, want it unchanged", mission)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Complete error = %v, want none", err)
+			}
+			if mission.Status != MissionStatusCompleted || !mission.IsCompleted {
+				t.Errorf("status = %q (is_completed=%v), want completed", mission.Status, mission.IsCompleted)
+			}
+			if mission.CompletedAt == nil || !mission.CompletedAt.Equal(at) || !mission.EndDate.Equal(at) {
+				t.Errorf("completed_at = %v, end_date = %v, want both %v", mission.CompletedAt, mission.EndDate, at)
+			}
+			if mission.CatID != nil {
+				t.Errorf("cat_id = %d, want the cat released", *mission.CatID)
+			}
+		})
+	}
+}
+
+func TestMissionAbort(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	catID := int32(7)
+
+	for _, from := range missionStatuses {
+		t.Run(string(from), func(t *testing.T) {
+			mission := &Mission{Status: from, CatID: &catID, Targets: []Target{
+				{ID: 1, Status: TargetStatusInit},
+				{ID: 2, Status: TargetStatusInProgress},
+				{ID: 3, Status: TargetStatusCompleted},
+			}}
+			err := mission.Abort("compromised", at)
+
+			if from.IsTerminal() {
+				var transitionErr *MissionTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("Abort error = %v, want a MissionTransitionError", err)
+				}
+				if mission.Status != from || mission.AbortedAt != nil || mission.Targets[0].Status != TargetStatusInit {
+					t.Errorf("mission = %+v after a refused abort, want it unchanged", mission)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Abort error = %v, want none", err)
+			}
+			if mission.Status != MissionStatusAborted || mission.AbortReason == nil || *mission.AbortReason != "compromised" || mission.AbortedAt == nil {
+				t.Errorf("mission = %+v, want aborted as compromised", mission)
+			}
+			if mission.CatID != nil {
+				t.Errorf("cat_id = %d, want the cat released", *mission.CatID)
+			}
+			want := []TargetStatus{TargetStatusFrozen, TargetStatusFrozen, TargetStatusCompleted}
+			for i, target := range mission.Targets {
+				if target.Status != want[i] {
+					t.Errorf("target %d status = %q, want %q", target.ID, target.Status, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMissionHandOver(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	catID := int32(7)
+
+	tests := []struct {
+		name    string
+		mission Mission
+		toCatID int32
+		wantErr error
+	}{
+		{name: "active", mission: Mission{ID: 1, Status: MissionStatusActive, CatID: &catID}, toCatID: 8},
+		{name: "assigned", mission: Mission{ID: 1, Status: MissionStatusAssigned, CatID: &catID}, toCatID: 8},
+		{name: "unstaffed", mission: Mission{ID: 1, Status: MissionStatusDraft}, toCatID: 8, wantErr: ErrMissionNotStaffed},
+		{name: "same cat", mission: Mission{ID: 1, Status: MissionStatusActive, CatID: &catID}, toCatID: 7, wantErr: ErrSameCatHandover},
+		{name: "completed", mission: Mission{ID: 1, Status: MissionStatusCompleted}, toCatID: 8, wantErr: ErrMissionFinished},
+		{name: "aborted", mission: Mission{ID: 1, Status: MissionStatusAborted}, toCatID: 8, wantErr: ErrMissionFinished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := tt.mission
+			handover, err := mission.HandOver(tt.toCatID, nil, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandOver error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if handover != nil || mission.CatID != tt.mission.CatID {
+					t.Errorf("handover = %+v, cat_id = %v after a refused handover, want none and unchanged", handover, mission.CatID)
+				}
+				return
+			}
+			if handover.MissionID != 1 || handover.FromCatID != 7 || handover.ToCatID != tt.toCatID || !handover.HandedOverAt.Equal(at) {
+				t.Errorf("handover = %+v, want mission 1 from cat 7 to cat %d", handover, tt.toCatID)
+			}
+			if mission.CatID == nil || *mission.CatID != tt.toCatID || mission.Status != tt.mission.Status {
+				t.Errorf("mission = %+v, want %s with cat %d", mission, tt.mission.Status, tt.toCatID)
+			}
+		})
+	}
+}
+
+func TestMissionFinishedGuards(t *testing.T) {
+	name := "Operation Catfish"
+
+	for _, status := range []MissionStatus{MissionStatusCompleted, MissionStatusAborted} {
+		t.Run(string(status), func(t *testing.T) {
+			mission := &Mission{Status: status, Name: "Operation Goldfish", Targets: []Target{{ID: 1, Name: "Dr. Fisherman", Status: TargetStatusInit}}}
+
+			if !mission.IsFinished() {
+				t.Error("IsFinished = false, want true")
+			}
+			if err := mission.EditDetails(&name, nil, nil, nil); !errors.Is(err, ErrMissionFinished) || mission.Name != "Operation Goldfish" {
+				t.Errorf("EditDetails error = %v, name = %q, want ErrMissionFinished and no change", err, mission.Name)
+			}
+			if _, err := mission.EditTarget(1, &name, nil, nil); !errors.Is(err, ErrMissionFinished) {
+				t.Errorf("EditTarget error = %v, want ErrMissionFinished", err)
+			}
+			if err := mission.CheckNewTarget("Baron Salmon"); !errors.Is(err, ErrMissionFinished) {
+				t.Errorf("CheckNewTarget error = %v, want ErrMissionFinished", err)
+			}
+		})
+	}
+
+	for _, status := range []MissionStatus{MissionStatusDraft, MissionStatusAssigned, MissionStatusActive} {
+		if (&Mission{Status: status}).IsFinished() {
+			t.Errorf("%s IsFinished = true, want false", status)
+		}
+	}
+}
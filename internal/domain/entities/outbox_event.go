@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	OutboxStatusDead      OutboxStatus = "dead"
+)
+
+func (s OutboxStatus) IsValid() bool {
+	switch s {
+	case OutboxStatusPending, OutboxStatusDelivered, OutboxStatusDead:
+		return true
+	}
+	return false
+}
+
+// OutboxEvent is a domain event stored in the same transaction as the change
+// that produced it, so it survives a crash between commit and delivery.
+type OutboxEvent struct {
+	ID            int64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventName     string       `json:"event_name" gorm:"size:100;not null;index"`
+	Payload       string       `json:"payload" gorm:"type:jsonb;not null"`
+	Status        OutboxStatus `json:"status" gorm:"size:20;not null;default:'pending';index:idx_outbox_due,priority:1"`
+	Attempts      int32        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" gorm:"not null;index:idx_outbox_due,priority:2"`
+	LastError     *string      `json:"last_error,omitempty" gorm:"type:text"`
+	OccurredAt    time.Time    `json:"occurred_at" gorm:"not null"`
+	DeliveredAt   *time.Time   `json:"delivered_at,omitempty"`
+	// DeliveredSinks lists the sinks that already accepted the event, comma
+	// separated, so retries only go to the sinks that failed.
+	DeliveredSinks string    `json:"-" gorm:"type:text;not null;default:''"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func NewOutboxEvent(name string, payload []byte, occurredAt time.Time) *OutboxEvent {
+	return &OutboxEvent{
+		EventName:     name,
+		Payload:       string(payload),
+		Status:        OutboxStatusPending,
+		OccurredAt:    occurredAt,
+		NextAttemptAt: occurredAt,
+	}
+}
+
+// DeliveredSinkNames returns the sinks that already accepted the event.
+func (e *OutboxEvent) DeliveredSinkNames() []string {
+	if e.DeliveredSinks == "" {
+		return nil
+	}
+	return strings.Split(e.DeliveredSinks, ",")
+}
+
+func (e *OutboxEvent) DeliveredTo(sink string) bool {
+	for _, name := range e.DeliveredSinkNames() {
+		if name == sink {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *OutboxEvent) RecordDelivery(sink string) {
+	if e.DeliveredTo(sink) {
+		return
+	}
+	e.DeliveredSinks = strings.Join(append(e.DeliveredSinkNames(), sink), ",")
+}
+
+// Replay puts a delivered or dead event back in the queue with a fresh
+// attempt budget, to be delivered to every sink again.
+func (e *OutboxEvent) Replay(at time.Time) {
+	e.Status = OutboxStatusPending
+	e.Attempts = 0
+	e.NextAttemptAt = at
+	e.LastError = nil
+	e.DeliveredAt = nil
+	e.DeliveredSinks = ""
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,8 +13,36 @@ const (
 	TargetStatusInit       TargetStatus = "init"
 	TargetStatusInProgress TargetStatus = "in_progress"
 	TargetStatusCompleted  TargetStatus = "completed"
+	TargetStatusFrozen     TargetStatus = "frozen"
 )
 
+// targetTransitions lists the statuses reachable from each status.
+// Targets only ever move forward, one step at a time; completed and frozen
+// are terminal.
+var targetTransitions = map[TargetStatus][]TargetStatus{
+	TargetStatusInit:       {TargetStatusInProgress, TargetStatusFrozen},
+	TargetStatusInProgress: {TargetStatusCompleted, TargetStatusFrozen},
+	TargetStatusCompleted:  {},
+	TargetStatusFrozen:     {},
+}
+
+func ParseTargetStatus(value string) (TargetStatus, error) {
+	status := TargetStatus(strings.ToLower(strings.TrimSpace(value)))
+	if !status.IsValid() {
+		return "", ErrUnknownTargetStatus
+	}
+	return status, nil
+}
+
+func (s TargetStatus) IsValid() bool {
+	_, ok := targetTransitions[s]
+	return ok
+}
+
+func (s TargetStatus) IsFinal() bool {
+	return s.IsValid() && len(targetTransitions[s]) == 0
+}
+
 type Target struct {
 	ID        int32          `gorm:"primaryKey;autoIncrement" json:"id"`
 	MissionID int32          `gorm:"not null;index" json:"mission_id"`
@@ -21,11 +50,69 @@ type Target struct {
 	Country   string         `gorm:"size:100;not null" json:"country"`
 	Notes     *string        `gorm:"type:text" json:"notes"`
 	Status    TargetStatus   `gorm:"size:20;not null;default:'init'" json:"status"`
+	Version   int32          `gorm:"not null;default:1" json:"version"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+func NewTarget(missionID int32, name, country string, notes *string) *Target {
+	return &Target{
+		MissionID: missionID,
+		Name:      name,
+		Country:   country,
+		Notes:     notes,
+		Status:    TargetStatusInit,
+	}
+}
+
 func (Target) TableName() string {
 	return "targets"
 }
+
+func (t *Target) IsCompleted() bool {
+	return t.Status == TargetStatusCompleted
+}
+
+func (t *Target) IsFinal() bool {
+	return t.Status.IsFinal()
+}
+
+func (t *Target) CanTransitionTo(next TargetStatus) bool {
+	for _, allowed := range targetTransitions[t.Status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the target to the next status. It is the only place a
+// target status may change; setting the current status again is a no-op
+// unless the target is already final.
+func (t *Target) TransitionTo(next TargetStatus) error {
+	if !next.IsValid() {
+		return ErrUnknownTargetStatus
+	}
+
+	if next == t.Status && !t.IsFinal() {
+		return nil
+	}
+
+	if !t.CanTransitionTo(next) {
+		return &TargetTransitionError{From: t.Status, To: next}
+	}
+
+	t.Status = next
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Freeze stops any further work on the target. Completed targets keep their
+// status, so freezing them is a no-op.
+func (t *Target) Freeze() error {
+	if t.IsCompleted() {
+		return nil
+	}
+	return t.TransitionTo(TargetStatusFrozen)
+}
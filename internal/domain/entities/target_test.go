@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+var targetStatuses = []TargetStatus{TargetStatusInit, TargetStatusInProgress, TargetStatusCompleted, TargetStatusFrozen}
+
+func TestTargetTransitionTo(t *testing.T) {
+	legal := map[TargetStatus][]TargetStatus{
+		TargetStatusInit:       {TargetStatusInit, TargetStatusInProgress, TargetStatusFrozen},
+		TargetStatusInProgress: {TargetStatusInProgress, TargetStatusCompleted, TargetStatusFrozen},
+	}
+
+	for _, from := range targetStatuses {
+		for _, to := range targetStatuses {
+			allowed := false
+			for _, next := range legal[from] {
+				allowed = allowed || next == to
+			}
+
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				target := &Target{Status: from}
+				err := target.TransitionTo(to)
+
+				if allowed {
+					if err != nil {
+						t.Fatalf("TransitionTo error = %v, want none", err)
+					}
+					if target.Status != to {
+						t.Errorf("status = %q, want %q", target.Status, to)
+					}
+					return
+				}
+
+				var transitionErr *TargetTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("TransitionTo error = %v, want a TargetTransitionError", err)
+				}
+				if transitionErr.From != from || transitionErr.To != to {
+					t.Errorf("error = %+v, want %s -> %s", transitionErr, from, to)
+				}
+				if target.Status != from {
+					t.Errorf("status = %q after a refused transition, want %q", target.Status, from)
+				}
+			})
+		}
+	}
+}
+
+func TestTargetTransitionToUnknownStatus(t *testing.T) {
+	target := &Target{Status: TargetStatusInit}
+	if err := target.TransitionTo("done"); !errors.Is(err, ErrUnknownTargetStatus) {
+		t.Errorf("TransitionTo error = %v, want ErrUnknownTargetStatus", err)
+	}
+}
+
+func TestTargetIsFinal(t *testing.T) {
+	for _, status := range targetStatuses {
+		want := status == TargetStatusCompleted || status == TargetStatusFrozen
+		if got := (&Target{Status: status}).IsFinal(); got != want {
+			t.Errorf("%s IsFinal = %v, want %v", status, got, want)
+		}
+	}
+	if TargetStatus("done").IsFinal() {
+		t.Error("unknown status IsFinal = true, want false")
+	}
+}
+
+func TestTargetFreeze(t *testing.T) {
+	tests := []struct {
+		from    TargetStatus
+		want    TargetStatus
+		wantErr bool
+	}{
+		{from: TargetStatusInit, want: TargetStatusFrozen},
+		{from: TargetStatusInProgress, want: TargetStatusFrozen},
+		{from: TargetStatusCompleted, want: TargetStatusCompleted},
+		{from: TargetStatusFrozen, want: TargetStatusFrozen, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			target := &Target{Status: tt.from}
+			err := target.Freeze()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Freeze error = %v, want error %v", err, tt.wantErr)
+			}
+			if target.Status != tt.want {
+				t.Errorf("status = %q, want %q", target.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookAllEvents subscribes a webhook to every event.
+const WebhookAllEvents = "*"
+
+type WebhookSubscription struct {
+	ID          int32          `json:"id" gorm:"primaryKey;autoIncrement"`
+	URL         string         `json:"url" gorm:"size:2048;not null"`
+	Secret      string         `json:"-" gorm:"size:128;not null"`
+	EventFilter string         `json:"-" gorm:"type:text;not null;default:'*'"`
+	Description *string        `json:"description,omitempty" gorm:"size:500"`
+	Active      bool           `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func NewWebhookSubscription(url, secret string, eventNames []string, description *string) *WebhookSubscription {
+	subscription := &WebhookSubscription{
+		URL:         url,
+		Secret:      secret,
+		Description: description,
+		Active:      true,
+	}
+	subscription.SetEvents(eventNames)
+	return subscription
+}
+
+// Events returns the event names the webhook listens to. An empty filter
+// means every event.
+func (w *WebhookSubscription) Events() []string {
+	if w.EventFilter == "" {
+		return []string{WebhookAllEvents}
+	}
+	return strings.Split(w.EventFilter, ",")
+}
+
+func (w *WebhookSubscription) SetEvents(eventNames []string) {
+	if len(eventNames) == 0 {
+		w.EventFilter = WebhookAllEvents
+		return
+	}
+	w.EventFilter = strings.Join(eventNames, ",")
+}
+
+func (w *WebhookSubscription) Matches(eventName string) bool {
+	if !w.Active {
+		return false
+	}
+	for _, name := range w.Events() {
+		if name == WebhookAllEvents || name == eventName {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery logs the delivery of one outbox event to one subscription.
+// The pair is unique, which is what keeps redelivery of an outbox event from
+// reaching a subscriber that already accepted it.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id" gorm:"primaryKey;autoIncrement"`
+	SubscriptionID int32                 `json:"subscription_id" gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:1"`
+	OutboxEventID  int64                 `json:"outbox_event_id" gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:2"`
+	EventName      string                `json:"event_name" gorm:"size:100;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"size:20;not null"`
+	Attempts       int32                 `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	LastError      *string               `json:"last_error,omitempty" gorm:"type:text"`
+	LastAttemptAt  time.Time             `json:"last_attempt_at" gorm:"not null"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Status == WebhookDeliverySucceeded
+}
+
+// RecordAttempt stores the outcome of one HTTP attempt. A nil error with a
+// 2xx response status marks the delivery as succeeded.
+func (d *WebhookDelivery) RecordAttempt(at time.Time, responseStatus *int, err error) {
+	d.Attempts++
+	d.LastAttemptAt = at
+	d.ResponseStatus = responseStatus
+
+	if err != nil {
+		message := err.Error()
+		d.Status = WebhookDeliveryFailed
+		d.LastError = &message
+		return
+	}
+
+	d.Status = WebhookDeliverySucceeded
+	d.LastError = nil
+	d.DeliveredAt = &at
+}
@@ -0,0 +1,239 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+)
+
+const (
+	NameMissionCreated      = "mission.created"
+	NameMissionUpdated      = "mission.updated"
+	NameMissionDeleted      = "mission.deleted"
+	NameCatAssigned         = "mission.cat_assigned"
+	NameMissionReassigned   = "mission.reassigned"
+	NameMissionAborted      = "mission.aborted"
+	NameMissionCompleted    = "mission.completed"
+	NameTargetAdded         = "target.added"
+	NameTargetDeleted       = "target.deleted"
+	NameTargetUpdated       = "target.updated"
+	NameTargetStatusChanged = "target.status_changed"
+	NameTargetNotesUpdated  = "target.notes_updated"
+	NameCatCreated          = "cat.created"
+	NameCatSalaryChanged    = "cat.salary_changed"
+	NameCatProfileUpdated   = "cat.profile_updated"
+	NameCatDeleted          = "cat.deleted"
+)
+
+// Names lists every event name, in the order they are declared above.
+var Names = []string{
+	NameMissionCreated,
+	NameMissionUpdated,
+	NameMissionDeleted,
+	NameCatAssigned,
+	NameMissionReassigned,
+	NameMissionAborted,
+	NameMissionCompleted,
+	NameTargetAdded,
+	NameTargetDeleted,
+	NameTargetUpdated,
+	NameTargetStatusChanged,
+	NameTargetNotesUpdated,
+	NameCatCreated,
+	NameCatSalaryChanged,
+	NameCatProfileUpdated,
+	NameCatDeleted,
+}
+
+func IsKnownName(name string) bool {
+	for _, known := range Names {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is something that already happened in the domain. Events are
+// published only after the change that produced them has been committed.
+type Event interface {
+	EventName() string
+	OccurredAt() time.Time
+}
+
+// Subscriber reacts to published events. A failing subscriber does not undo
+// the change that produced the event.
+type Subscriber interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+type SubscriberFunc func(ctx context.Context, event Event) error
+
+func (f SubscriberFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+type MissionCreated struct {
+	MissionID   int32     `json:"mission_id"`
+	Name        string    `json:"name"`
+	TargetCount int       `json:"target_count"`
+	At          time.Time `json:"occurred_at"`
+}
+
+func (e MissionCreated) EventName() string     { return NameMissionCreated }
+func (e MissionCreated) OccurredAt() time.Time { return e.At }
+
+type MissionUpdated struct {
+	MissionID   int32      `json:"mission_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	At          time.Time  `json:"occurred_at"`
+}
+
+func (e MissionUpdated) EventName() string     { return NameMissionUpdated }
+func (e MissionUpdated) OccurredAt() time.Time { return e.At }
+
+type MissionDeleted struct {
+	MissionID int32     `json:"mission_id"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e MissionDeleted) EventName() string     { return NameMissionDeleted }
+func (e MissionDeleted) OccurredAt() time.Time { return e.At }
+
+type CatAssigned struct {
+	MissionID int32     `json:"mission_id"`
+	CatID     int32     `json:"cat_id"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e CatAssigned) EventName() string     { return NameCatAssigned }
+func (e CatAssigned) OccurredAt() time.Time { return e.At }
+
+type MissionReassigned struct {
+	MissionID int32     `json:"mission_id"`
+	FromCatID int32     `json:"from_cat_id"`
+	ToCatID   int32     `json:"to_cat_id"`
+	Reason    *string   `json:"reason,omitempty"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e MissionReassigned) EventName() string     { return NameMissionReassigned }
+func (e MissionReassigned) OccurredAt() time.Time { return e.At }
+
+type MissionAborted struct {
+	MissionID int32     `json:"mission_id"`
+	CatID     *int32    `json:"cat_id,omitempty"`
+	Reason    string    `json:"reason"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e MissionAborted) EventName() string     { return NameMissionAborted }
+func (e MissionAborted) OccurredAt() time.Time { return e.At }
+
+type MissionCompleted struct {
+	MissionID int32     `json:"mission_id"`
+	CatID     *int32    `json:"cat_id,omitempty"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e MissionCompleted) EventName() string     { return NameMissionCompleted }
+func (e MissionCompleted) OccurredAt() time.Time { return e.At }
+
+type TargetAdded struct {
+	MissionID int32     `json:"mission_id"`
+	TargetID  int32     `json:"target_id"`
+	Name      string    `json:"name"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e TargetAdded) EventName() string     { return NameTargetAdded }
+func (e TargetAdded) OccurredAt() time.Time { return e.At }
+
+type TargetDeleted struct {
+	MissionID int32     `json:"mission_id"`
+	TargetID  int32     `json:"target_id"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e TargetDeleted) EventName() string     { return NameTargetDeleted }
+func (e TargetDeleted) OccurredAt() time.Time { return e.At }
+
+type TargetUpdated struct {
+	MissionID int32     `json:"mission_id"`
+	TargetID  int32     `json:"target_id"`
+	Name      string    `json:"name"`
+	Country   string    `json:"country"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e TargetUpdated) EventName() string     { return NameTargetUpdated }
+func (e TargetUpdated) OccurredAt() time.Time { return e.At }
+
+type TargetStatusChanged struct {
+	MissionID int32                 `json:"mission_id"`
+	TargetID  int32                 `json:"target_id"`
+	From      entities.TargetStatus `json:"from"`
+	To        entities.TargetStatus `json:"to"`
+	At        time.Time             `json:"occurred_at"`
+}
+
+func (e TargetStatusChanged) EventName() string     { return NameTargetStatusChanged }
+func (e TargetStatusChanged) OccurredAt() time.Time { return e.At }
+
+type TargetNotesUpdated struct {
+	MissionID int32     `json:"mission_id"`
+	TargetID  int32     `json:"target_id"`
+	CatID     int32     `json:"cat_id"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e TargetNotesUpdated) EventName() string     { return NameTargetNotesUpdated }
+func (e TargetNotesUpdated) OccurredAt() time.Time { return e.At }
+
+type CatCreated struct {
+	CatID  int32     `json:"cat_id"`
+	Name   string    `json:"name"`
+	Breed  string    `json:"breed"`
+	Salary float64   `json:"salary"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (e CatCreated) EventName() string     { return NameCatCreated }
+func (e CatCreated) OccurredAt() time.Time { return e.At }
+
+type CatSalaryChanged struct {
+	CatID     int32     `json:"cat_id"`
+	OldSalary float64   `json:"old_salary"`
+	NewSalary float64   `json:"new_salary"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e CatSalaryChanged) EventName() string     { return NameCatSalaryChanged }
+func (e CatSalaryChanged) OccurredAt() time.Time { return e.At }
+
+type CatProfileUpdated struct {
+	CatID             int32     `json:"cat_id"`
+	Name              string    `json:"name"`
+	Breed             string    `json:"breed"`
+	YearsOfExperience int32     `json:"years_of_experience"`
+	At                time.Time `json:"occurred_at"`
+}
+
+func (e CatProfileUpdated) EventName() string     { return NameCatProfileUpdated }
+func (e CatProfileUpdated) OccurredAt() time.Time { return e.At }
+
+type CatDeleted struct {
+	CatID int32     `json:"cat_id"`
+	At    time.Time `json:"occurred_at"`
+}
+
+func (e CatDeleted) EventName() string     { return NameCatDeleted }
+func (e CatDeleted) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	// GetByPrefix returns nil when no key has the prefix.
+	GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	List(ctx context.Context) ([]*entities.APIKey, error)
+	// Revoke keeps the original revocation time of a key revoked twice.
+	Revoke(ctx context.Context, id int32, at time.Time) (*entities.APIKey, error)
+	// TouchLastUsed records a use unless one was recorded less than
+	// granularity ago, which keeps busy keys from writing on every request.
+	TouchLastUsed(ctx context.Context, id int32, at time.Time, granularity time.Duration) error
+	WithTx(tx *gorm.DB) APIKeyRepository
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type AuditFilter struct {
+	EntityType *entities.AuditEntityType
+	EntityID   *int64
+	Actor      *string
+	From       *time.Time
+	To         *time.Time
+	Limit      int32
+	Offset     int32
+}
+
+type AuditRepository interface {
+	// Record stores audit entries. Call it on a repository bound to the
+	// transaction of the change being audited.
+	Record(ctx context.Context, entries ...*entities.AuditEntry) error
+	// List returns matching entries, newest first.
+	List(ctx context.Context, filter AuditFilter) ([]*entities.AuditEntry, error)
+	WithTx(tx *gorm.DB) AuditRepository
+}
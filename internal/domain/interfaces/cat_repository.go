@@ -6,12 +6,45 @@ import (
 	"gorm.io/gorm"
 )
 
+// CatSortFields are the columns cats may be sorted by.
+var CatSortFields = []string{"id", "name", "salary", "years_of_experience"}
+
+func IsCatSortField(field string) bool {
+	for _, allowed := range CatSortFields {
+		if allowed == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CatFilter narrows a cat listing. Nil fields are not filtered on; Available
+// selects free (true) or busy (false) cats. A zero Limit returns every match,
+// and After replaces Offset with keyset pagination.
+type CatFilter struct {
+	Breed         *string
+	MinExperience *int32
+	MaxExperience *int32
+	MinSalary     *float64
+	MaxSalary     *float64
+	Available     *bool
+	Name          *string
+	SortBy        string
+	SortDesc      bool
+	After         *Cursor
+	Limit         int32
+	Offset        int32
+}
+
 type CatRepository interface {
 	Create(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error)
 	GetByID(ctx context.Context, id int32) (*entities.SpyCat, error)
-	List(ctx context.Context, limit, offset int32) ([]*entities.SpyCat, error)
-	UpdateSalary(ctx context.Context, id int32, salary float64) (*entities.SpyCat, error)
-	Delete(ctx context.Context, id int32) error
+	GetByIDForUpdate(ctx context.Context, id int32) (*entities.SpyCat, error)
+	List(ctx context.Context, filter CatFilter) ([]*entities.SpyCat, error)
+	Count(ctx context.Context, filter CatFilter) (int64, error)
+	UpdateSalary(ctx context.Context, id int32, salary float64, expectedVersion *int32) (*entities.SpyCat, error)
+	UpdateProfile(ctx context.Context, cat *entities.SpyCat) (*entities.SpyCat, error)
+	Delete(ctx context.Context, id int32, expectedVersion *int32) error
 	UnassignFromMission(ctx context.Context, catID int32) error
 	AssignToMission(ctx context.Context, catID, missionID int32) error
 	WithTx(tx *gorm.DB) CatRepository
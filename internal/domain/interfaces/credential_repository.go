@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"spy-cat-agency/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type CredentialRepository interface {
+	Create(ctx context.Context, credential *entities.Credential) error
+	// GetByUsername returns nil when no credential has the username.
+	GetByUsername(ctx context.Context, username string) (*entities.Credential, error)
+	// GetByCatID returns nil when the cat has no login.
+	GetByCatID(ctx context.Context, catID int32) (*entities.Credential, error)
+	Update(ctx context.Context, credential *entities.Credential) error
+	WithTx(tx *gorm.DB) CredentialRepository
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"spy-cat-agency/internal/domain/entities"
+)
+
+// ExportRepository streams every row matching a filter to fn, one at a time,
+// without loading the result set into memory. Limit, Offset and After are
+// ignored. Targets are selected by the filter of their mission. A non-nil
+// error from fn stops the stream and is returned as is.
+type ExportRepository interface {
+	StreamCats(ctx context.Context, filter CatFilter, fn func(*entities.SpyCat) error) error
+	StreamMissions(ctx context.Context, filter MissionFilter, fn func(*entities.Mission) error) error
+	StreamTargets(ctx context.Context, filter MissionFilter, fn func(*entities.Target) error) error
+}
@@ -1,19 +1,68 @@
 package interfaces
 
 import (
+	"time"
+
 	"spy-cat-agency/internal/domain/entities"
 
 	"gorm.io/gorm"
 )
 
+// MissionSortFields are the columns missions may be sorted by.
+var MissionSortFields = []string{"id", "name", "status", "start_date", "end_date", "created_at"}
+
+func IsMissionSortField(field string) bool {
+	for _, allowed := range MissionSortFields {
+		if allowed == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MissionFilter narrows a mission listing. Nil fields are not filtered on;
+// From and To select missions whose planned period overlaps that range.
+// A zero Limit returns every match, and After replaces Offset with keyset
+// pagination.
+type MissionFilter struct {
+	Status      *entities.MissionStatus
+	IsCompleted *bool
+	CatID       *int32
+	Country     *string
+	From        *time.Time
+	To          *time.Time
+	Name        *string
+	SortBy      string
+	SortDesc    bool
+	After       *Cursor
+	Limit       int32
+	Offset      int32
+}
+
+// MissionAssignmentFilter pages through one cat's mission history.
+type MissionAssignmentFilter struct {
+	CatID  int32
+	After  *Cursor
+	Limit  int32
+	Offset int32
+}
+
 type MissionRepository interface {
 	Create(mission *entities.Mission) (*entities.Mission, error)
-	GetAll() ([]*entities.Mission, error)
+	List(filter MissionFilter) ([]*entities.Mission, error)
+	Count(filter MissionFilter) (int64, error)
 	GetByID(id int32) (*entities.Mission, error)
-	Delete(id int32) error
+	GetByIDForUpdate(id int32) (*entities.Mission, error)
+	Delete(id int32, expectedVersion *int32) error
 	CheckMissionExists(id int32) (bool, error)
 	Update(mission *entities.Mission) (*entities.Mission, error)
 	AssignCatToMission(missionID, catID int32) error
+	CreateHandover(handover *entities.MissionHandover) error
+	OpenAssignment(assignment *entities.MissionAssignment) error
+	CloseAssignment(missionID int32, reason entities.AssignmentEndReason, at time.Time) error
+	ListAssignments(filter MissionAssignmentFilter) ([]*entities.MissionAssignment, error)
+	CountAssignments(catID int32) (int64, error)
+	DeleteHistory(missionID int32) error
 	UnassignCatFromMission(catID int32) error
 	GetFreeCats() ([]*entities.SpyCat, error)
 	WithTx(tx *gorm.DB) MissionRepository
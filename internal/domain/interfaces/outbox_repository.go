@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+
+	"gorm.io/gorm"
+)
+
+type OutboxFilter struct {
+	Status *entities.OutboxStatus
+	Limit  int32
+	Offset int32
+}
+
+type OutboxRepository interface {
+	// Append stores events for later delivery. Call it on a repository bound
+	// to the transaction of the change that produced the events.
+	Append(ctx context.Context, published ...events.Event) error
+	// ClaimDue leases up to limit due pending events until now+lease, so other
+	// dispatchers skip them while they are being delivered.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed and MarkDead also store the sinks that accepted the event,
+	// as in OutboxEvent.DeliveredSinks, so a retry skips them.
+	MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, deliveredSinks, lastError string) error
+	MarkDead(ctx context.Context, id int64, deliveredSinks, lastError string) error
+	List(ctx context.Context, filter OutboxFilter) ([]*entities.OutboxEvent, error)
+	Replay(ctx context.Context, id int64) (*entities.OutboxEvent, error)
+	WithTx(tx *gorm.DB) OutboxRepository
+}
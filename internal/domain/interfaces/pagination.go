@@ -0,0 +1,92 @@
+package interfaces
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+)
+
+// Cursor is a keyset position: the sort value and id of the last row of a
+// page. The next page starts strictly after that row, so rows inserted or
+// removed meanwhile do not shift it. Clients only see the encoded form.
+type Cursor struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  string `json:"v,omitempty"`
+	ID     int32  `json:"i"`
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, entities.ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID <= 0 || cursor.SortBy == "" {
+		return nil, entities.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Matches reports whether the cursor was issued for the given ordering.
+func (c Cursor) Matches(sortBy string, desc bool) bool {
+	return c.SortBy == sortBy && c.Desc == desc
+}
+
+func MissionCursor(mission *entities.Mission, sortBy string, desc bool) Cursor {
+	if !IsMissionSortField(sortBy) {
+		sortBy = "id"
+	}
+
+	cursor := Cursor{SortBy: sortBy, Desc: desc, ID: mission.ID}
+	switch sortBy {
+	case "name":
+		cursor.Value = mission.Name
+	case "status":
+		cursor.Value = string(mission.Status)
+	case "start_date":
+		cursor.Value = mission.StartDate.Format(time.RFC3339Nano)
+	case "end_date":
+		cursor.Value = mission.EndDate.Format(time.RFC3339Nano)
+	case "created_at":
+		cursor.Value = mission.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+func CatCursor(cat *entities.SpyCat, sortBy string, desc bool) Cursor {
+	if !IsCatSortField(sortBy) {
+		sortBy = "id"
+	}
+
+	cursor := Cursor{SortBy: sortBy, Desc: desc, ID: cat.ID}
+	switch sortBy {
+	case "name":
+		cursor.Value = cat.Name
+	case "salary":
+		cursor.Value = strconv.FormatFloat(cat.Salary, 'f', -1, 64)
+	case "years_of_experience":
+		cursor.Value = strconv.FormatInt(int64(cat.YearsOfExperience), 10)
+	}
+	return cursor
+}
+
+// AssignmentCursor positions a cat's mission history, which is always listed
+// newest first.
+func AssignmentCursor(assignment *entities.MissionAssignment) Cursor {
+	return Cursor{
+		SortBy: "assigned_at",
+		Desc:   true,
+		Value:  assignment.AssignedAt.Format(time.RFC3339Nano),
+		ID:     assignment.ID,
+	}
+}
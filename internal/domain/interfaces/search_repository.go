@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultMission SearchResultType = "mission"
+	SearchResultTarget  SearchResultType = "target"
+)
+
+func (t SearchResultType) IsValid() bool {
+	return t == SearchResultMission || t == SearchResultTarget
+}
+
+// SearchQuery is a free-text query in web search syntax ("quoted phrases",
+// -excluded words, or). An empty Types searches everything.
+type SearchQuery struct {
+	Text   string
+	Types  []SearchResultType
+	Limit  int32
+	Offset int32
+}
+
+// SearchHit is one ranked match. Snippet holds the matching text, HTML
+// escaped, with the matched words wrapped in <mark>.
+type SearchHit struct {
+	Type      SearchResultType
+	ID        int32
+	MissionID int32
+	Title     string
+	Snippet   string
+	Rank      float32
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, query SearchQuery) ([]SearchHit, error)
+	Count(ctx context.Context, query SearchQuery) (int64, error)
+}
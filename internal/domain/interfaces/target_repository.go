@@ -13,9 +13,9 @@ type TargetRepository interface {
 	GetByMissionID(ctx context.Context, missionID int32) ([]*entities.Target, error)
 	GetByID(ctx context.Context, id int32) (*entities.Target, error)
 	Update(ctx context.Context, target *entities.Target) (*entities.Target, error)
-	Delete(ctx context.Context, id int32) error
+	Delete(ctx context.Context, target *entities.Target) error
 	DeleteByMissionID(ctx context.Context, missionID int32) error
-	UpdateStatus(ctx context.Context, id int32, status entities.TargetStatus) error
-	UpdateNotes(ctx context.Context, id int32, notes string) error
+	UpdateStatus(ctx context.Context, target *entities.Target) error
+	UpdateNotes(ctx context.Context, target *entities.Target) error
 	WithTx(tx *gorm.DB) TargetRepository
 }
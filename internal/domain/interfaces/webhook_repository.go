@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"spy-cat-agency/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id int32) (*entities.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int32) error
+	// GetDelivery returns nil when the event was never sent to the subscription.
+	GetDelivery(ctx context.Context, subscriptionID int32, outboxEventID int64) (*entities.WebhookDelivery, error)
+	SaveDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, subscriptionID int32, limit, offset int32) ([]*entities.WebhookDelivery, error)
+	WithTx(tx *gorm.DB) WebhookRepository
+}
@@ -67,15 +67,46 @@ func NewConnection(cfg Config) (*DB, error) {
 }
 
 func (db *DB) AutoMigrate() error {
+	hadMissionStatus := db.DB.Migrator().HasColumn(&entities.Mission{}, "status")
+	hadMissionAssignments := db.DB.Migrator().HasTable(&entities.MissionAssignment{})
+
 	err := db.DB.AutoMigrate(
 		&entities.SpyCat{},
 		&entities.Mission{},
 		&entities.Target{},
+		&entities.MissionHandover{},
+		&entities.MissionAssignment{},
+		&entities.OutboxEvent{},
+		&entities.WebhookSubscription{},
+		&entities.WebhookDelivery{},
+		&entities.Credential{},
+		&entities.APIKey{},
+		&entities.AuditEntry{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to auto-migrate: %w", err)
 	}
 
+	if !hadMissionStatus {
+		if err := db.backfillMissionStatus(); err != nil {
+			return err
+		}
+	}
+
+	if err := db.ensureSearchSchema(); err != nil {
+		return err
+	}
+
+	if err := db.migrateStaffRole(); err != nil {
+		return err
+	}
+
+	if !hadMissionAssignments {
+		if err := db.backfillMissionAssignments(); err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database auto-migration completed successfully")
 	return nil
 }
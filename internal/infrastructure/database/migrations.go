@@ -0,0 +1,128 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// backfillMissionStatus derives the lifecycle status of missions that existed
+// before the status column was introduced.
+func (db *DB) backfillMissionStatus() error {
+	result := db.DB.Exec(`
+		UPDATE missions SET status = CASE
+			WHEN is_completed THEN 'completed'
+			WHEN cat_id IS NULL THEN 'draft'
+			WHEN EXISTS (
+				SELECT 1 FROM targets
+				WHERE targets.mission_id = missions.id
+					AND targets.deleted_at IS NULL
+					AND targets.status <> 'init'
+			) THEN 'active'
+			ELSE 'assigned'
+		END
+	`)
+	if result.Error != nil {
+		return fmt.Errorf("failed to backfill mission status: %w", result.Error)
+	}
+
+	log.Printf("Backfilled status for %d missions", result.RowsAffected)
+	return nil
+}
+
+// backfillMissionAssignments rebuilds cat mission history from handovers and
+// the current mission staffing. Finished missions no longer name their cat, so
+// for those only the cats known from handovers can be recovered.
+func (db *DB) backfillMissionAssignments() error {
+	var inserted int64
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		handedOver := tx.Exec(`
+			INSERT INTO mission_assignments (mission_id, cat_id, assigned_at, ended_at, end_reason, created_at)
+			SELECT h.mission_id, h.from_cat_id,
+				COALESCE(LAG(h.handed_over_at) OVER w, m.start_date),
+				h.handed_over_at, 'reassigned', now()
+			FROM mission_handovers h
+			JOIN missions m ON m.id = h.mission_id
+			WINDOW w AS (PARTITION BY h.mission_id ORDER BY h.handed_over_at, h.id)
+		`)
+		if handedOver.Error != nil {
+			return handedOver.Error
+		}
+
+		current := tx.Exec(`
+			INSERT INTO mission_assignments (mission_id, cat_id, assigned_at, ended_at, end_reason, created_at)
+			SELECT m.id, COALESCE(m.cat_id, last.to_cat_id),
+				COALESCE(last.handed_over_at, m.start_date),
+				CASE m.status WHEN 'completed' THEN m.completed_at WHEN 'aborted' THEN m.aborted_at END,
+				CASE WHEN m.status IN ('completed', 'aborted') THEN m.status END,
+				now()
+			FROM missions m
+			LEFT JOIN LATERAL (
+				SELECT to_cat_id, handed_over_at FROM mission_handovers
+				WHERE mission_handovers.mission_id = m.id
+				ORDER BY handed_over_at DESC, id DESC
+				LIMIT 1
+			) last ON true
+			WHERE COALESCE(m.cat_id, last.to_cat_id) IS NOT NULL
+		`)
+		if current.Error != nil {
+			return current.Error
+		}
+
+		inserted = handedOver.RowsAffected + current.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to backfill mission assignments: %w", err)
+	}
+
+	log.Printf("Backfilled %d mission assignments", inserted)
+	return nil
+}
+
+// searchSchema keeps full-text search vectors as generated columns, so Postgres
+// recomputes them on every write to the source columns, notes included.
+var searchSchema = []string{
+	`CREATE OR REPLACE FUNCTION search_escape_html(value text) RETURNS text
+		LANGUAGE sql IMMUTABLE AS
+		$$ SELECT replace(replace(replace(value, '&', '&amp;'), '<', '&lt;'), '>', '&gt;') $$`,
+	`ALTER TABLE missions ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_missions_search_vector ON missions USING GIN (search_vector)`,
+	`ALTER TABLE targets ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(country, '')), 'B') ||
+			setweight(to_tsvector('english', coalesce(notes, '')), 'C')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_targets_search_vector ON targets USING GIN (search_vector)`,
+}
+
+func (db *DB) ensureSearchSchema() error {
+	for _, statement := range searchSchema {
+		if err := db.DB.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to set up full-text search: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateStaffRole turns logins created before staff roles existed into
+// directors, which keeps them able to do everything they could before.
+func (db *DB) migrateStaffRole() error {
+	result := db.DB.Model(&entities.Credential{}).Where("role = ?", "staff").Update("role", auth.RoleDirector)
+	if result.Error != nil {
+		return fmt.Errorf("failed to migrate staff roles: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Migrated %d staff logins to the director role", result.RowsAffected)
+	}
+	return nil
+}
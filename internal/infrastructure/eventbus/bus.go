@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"spy-cat-agency/internal/domain/events"
+)
+
+// Bus delivers events synchronously to in-process subscribers. Subscriber
+// errors and panics are logged and never reach the publisher, because the
+// change behind the event is already committed.
+type Bus struct {
+	mu     sync.RWMutex
+	byName map[string][]events.Subscriber
+	all    []events.Subscriber
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		byName: make(map[string][]events.Subscriber),
+	}
+}
+
+// Subscribe registers a subscriber for the given event names, or for every
+// event when no names are given.
+func (b *Bus) Subscribe(subscriber events.Subscriber, names ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(names) == 0 {
+		b.all = append(b.all, subscriber)
+		return
+	}
+
+	for _, name := range names {
+		b.byName[name] = append(b.byName[name], subscriber)
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, published ...events.Event) {
+	for _, event := range published {
+		b.mu.RLock()
+		subscribers := make([]events.Subscriber, 0, len(b.all)+len(b.byName[event.EventName()]))
+		subscribers = append(subscribers, b.all...)
+		subscribers = append(subscribers, b.byName[event.EventName()]...)
+		b.mu.RUnlock()
+
+		for _, subscriber := range subscribers {
+			deliver(ctx, subscriber, event)
+		}
+	}
+}
+
+func deliver(ctx context.Context, subscriber events.Subscriber, event events.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event subscriber panicked on %s: %v", event.EventName(), r)
+		}
+	}()
+
+	if err := subscriber.Handle(ctx, event); err != nil {
+		log.Printf("Event subscriber failed on %s: %v", event.EventName(), err)
+	}
+}
+
+// LogSubscriber writes every event to the standard logger next to the
+// API_LOG request lines.
+func LogSubscriber() events.Subscriber {
+	return events.SubscriberFunc(func(ctx context.Context, event events.Event) error {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		log.Printf("DOMAIN_EVENT: %s %s", event.EventName(), payload)
+		return nil
+	})
+}
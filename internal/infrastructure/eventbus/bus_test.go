@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"spy-cat-agency/internal/domain/events"
+)
+
+// recorder returns a subscriber that appends "<label> <event name>" to log.
+func recorder(log *[]string, label string) events.Subscriber {
+	return events.SubscriberFunc(func(ctx context.Context, event events.Event) error {
+		*log = append(*log, fmt.Sprintf("%s %s", label, event.EventName()))
+		return nil
+	})
+}
+
+func TestPublishDeliversInOrder(t *testing.T) {
+	bus := NewBus()
+	var log []string
+	bus.Subscribe(recorder(&log, "missions"), events.NameMissionCreated, events.NameMissionDeleted)
+	bus.Subscribe(recorder(&log, "all"))
+	bus.Subscribe(recorder(&log, "targets"), events.NameTargetAdded)
+
+	bus.Publish(context.Background(),
+		events.MissionCreated{MissionID: 1},
+		events.TargetAdded{MissionID: 1, TargetID: 2},
+		events.MissionDeleted{MissionID: 1},
+	)
+
+	want := []string{
+		"all " + events.NameMissionCreated,
+		"missions " + events.NameMissionCreated,
+		"all " + events.NameTargetAdded,
+		"targets " + events.NameTargetAdded,
+		"all " + events.NameMissionDeleted,
+		"missions " + events.NameMissionDeleted,
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("deliveries = %v, want %v", log, want)
+	}
+}
+
+func TestPublishWithoutSubscribers(t *testing.T) {
+	NewBus().Publish(context.Background(), events.MissionCreated{MissionID: 1})
+}
+
+func TestPublishSurvivesFailingSubscribers(t *testing.T) {
+	bus := NewBus()
+	var log []string
+	bus.Subscribe(recorder(&log, "first"))
+	bus.Subscribe(events.SubscriberFunc(func(ctx context.Context, event events.Event) error {
+		panic("subscriber bug")
+	}))
+	bus.Subscribe(events.SubscriberFunc(func(ctx context.Context, event events.Event) error {
+		return errors.New("subscriber failed")
+	}))
+	bus.Subscribe(recorder(&log, "last"))
+
+	bus.Publish(context.Background(), events.MissionCreated{MissionID: 1}, events.MissionDeleted{MissionID: 1})
+
+	want := []string{
+		"first " + events.NameMissionCreated,
+		"last " + events.NameMissionCreated,
+		"first " + events.NameMissionDeleted,
+		"last " + events.NameMissionDeleted,
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("deliveries = %v, want %v", log, want)
+	}
+}
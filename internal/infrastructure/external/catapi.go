@@ -6,8 +6,14 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"spy-cat-agency/internal/domain/apperrors"
 )
 
+// ErrBreedsUnavailable is returned when TheCatAPI cannot be reached and no
+// cached breed list is available.
+var ErrBreedsUnavailable = apperrors.Unavailable("breed_service_unavailable", "unable to connect to breed validation service")
+
 type Breed struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -49,17 +55,17 @@ func (s *BreedService) GetBreeds() ([]Breed, error) {
 
 	resp, err := s.client.Get("https://api.thecatapi.com/v1/breeds")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch breeds from TheCatAPI: %w", err)
+		return nil, ErrBreedsUnavailable.WithDetail(fmt.Sprintf("failed to fetch breeds from TheCatAPI: %v", err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TheCatAPI returned status %d", resp.StatusCode)
+		return nil, ErrBreedsUnavailable.WithDetail(fmt.Sprintf("TheCatAPI returned status %d", resp.StatusCode))
 	}
 
 	var breeds []Breed
 	if err := json.NewDecoder(resp.Body).Decode(&breeds); err != nil {
-		return nil, fmt.Errorf("failed to decode breeds response: %w", err)
+		return nil, ErrBreedsUnavailable.WithDetail(fmt.Sprintf("failed to decode breeds response: %v", err))
 	}
 
 	s.cache = breeds
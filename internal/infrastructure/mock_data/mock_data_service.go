@@ -1,12 +1,15 @@
 package mock_data
 
 import (
+	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"spy-cat-agency/internal/domain/entities"
 	"spy-cat-agency/internal/infrastructure/database"
 
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -16,11 +19,15 @@ func stringPtr(s string) *string {
 
 type MockDataService struct {
 	db *database.DB
+	// catPassword is the password of every seeded spy cat login, whose
+	// username is the lowercased cat name. Without one no logins are seeded.
+	catPassword string
 }
 
-func NewMockDataService(db *database.DB) *MockDataService {
+func NewMockDataService(db *database.DB, catPassword string) *MockDataService {
 	return &MockDataService{
-		db: db,
+		db:          db,
+		catPassword: catPassword,
 	}
 }
 
@@ -28,20 +35,53 @@ func (m *MockDataService) WipeAndSeedData() error {
 	log.Println("🧹 Wiping existing data...")
 
 	// Clear all data from tables (in correct order due to foreign keys)
+	if err := m.db.Exec("DELETE FROM webhook_deliveries").Error; err != nil {
+		return err
+	}
+	if err := m.db.Exec("DELETE FROM outbox_events").Error; err != nil {
+		return err
+	}
+	if err := m.db.Exec("DELETE FROM audit_log").Error; err != nil {
+		return err
+	}
 	if err := m.db.Exec("DELETE FROM targets").Error; err != nil {
 		return err
 	}
+	if err := m.db.Exec("DELETE FROM mission_handovers").Error; err != nil {
+		return err
+	}
+	if err := m.db.Exec("DELETE FROM mission_assignments").Error; err != nil {
+		return err
+	}
 	if err := m.db.Exec("DELETE FROM missions").Error; err != nil {
 		return err
 	}
+	if err := m.db.Exec("DELETE FROM credentials WHERE cat_id IS NOT NULL").Error; err != nil {
+		return err
+	}
 	if err := m.db.Exec("DELETE FROM spy_cats").Error; err != nil {
 		return err
 	}
 
 	// Reset auto-increment sequences
+	if err := m.db.Exec("ALTER SEQUENCE webhook_deliveries_id_seq RESTART WITH 1").Error; err != nil {
+		log.Printf("Warning: Could not reset webhook_deliveries sequence: %v", err)
+	}
+	if err := m.db.Exec("ALTER SEQUENCE outbox_events_id_seq RESTART WITH 1").Error; err != nil {
+		log.Printf("Warning: Could not reset outbox_events sequence: %v", err)
+	}
+	if err := m.db.Exec("ALTER SEQUENCE audit_log_id_seq RESTART WITH 1").Error; err != nil {
+		log.Printf("Warning: Could not reset audit_log sequence: %v", err)
+	}
 	if err := m.db.Exec("ALTER SEQUENCE targets_id_seq RESTART WITH 1").Error; err != nil {
 		log.Printf("Warning: Could not reset targets sequence: %v", err)
 	}
+	if err := m.db.Exec("ALTER SEQUENCE mission_handovers_id_seq RESTART WITH 1").Error; err != nil {
+		log.Printf("Warning: Could not reset mission_handovers sequence: %v", err)
+	}
+	if err := m.db.Exec("ALTER SEQUENCE mission_assignments_id_seq RESTART WITH 1").Error; err != nil {
+		log.Printf("Warning: Could not reset mission_assignments sequence: %v", err)
+	}
 	if err := m.db.Exec("ALTER SEQUENCE missions_id_seq RESTART WITH 1").Error; err != nil {
 		log.Printf("Warning: Could not reset missions sequence: %v", err)
 	}
@@ -92,6 +132,19 @@ func (m *MockDataService) WipeAndSeedData() error {
 			}
 		}
 
+		if m.catPassword != "" {
+			passwordHash, err := bcrypt.GenerateFromPassword([]byte(m.catPassword), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+			for _, cat := range cats {
+				credential := entities.NewCatCredential(cat.ID, strings.ToLower(cat.Name), string(passwordHash))
+				if err := tx.Create(credential).Error; err != nil {
+					return err
+				}
+			}
+		}
+
 		now := time.Now()
 
 		// Create missions in different states
@@ -102,8 +155,7 @@ func (m *MockDataService) WipeAndSeedData() error {
 				Description: "Infiltrate the aquarium and gather intelligence on the rare goldfish smuggling operation.",
 				StartDate:   now.AddDate(0, 0, -5),
 				EndDate:     now.AddDate(0, 0, 10),
-				CatID:       &cats[0].ID, // Shadow
-				IsCompleted: false,
+				Status:      entities.MissionStatusDraft,
 			},
 			// Pending mission (no cat assigned)
 			{
@@ -111,7 +163,7 @@ func (m *MockDataService) WipeAndSeedData() error {
 				Description: "Investigate the underground catnip distribution network in the city.",
 				StartDate:   now.AddDate(0, 0, 2),
 				EndDate:     now.AddDate(0, 0, 20),
-				IsCompleted: false,
+				Status:      entities.MissionStatusDraft,
 			},
 			// Completed mission
 			{
@@ -119,8 +171,7 @@ func (m *MockDataService) WipeAndSeedData() error {
 				Description: "Successfully completed mission to eliminate the mouse infestation in the warehouse district.",
 				StartDate:   now.AddDate(0, 0, -30),
 				EndDate:     now.AddDate(0, 0, -10),
-				IsCompleted: true,
-				CompletedAt: func() *time.Time { t := now.AddDate(0, 0, -12); return &t }(),
+				Status:      entities.MissionStatusDraft,
 			},
 			// Another active mission with different cat
 			{
@@ -128,17 +179,39 @@ func (m *MockDataService) WipeAndSeedData() error {
 				Description: "Undercover operation to infiltrate the yarn manufacturing facility and uncover quality control secrets.",
 				StartDate:   now.AddDate(0, 0, -3),
 				EndDate:     now.AddDate(0, 0, 15),
-				CatID:       &cats[2].ID, // Mittens
-				IsCompleted: false,
+				Status:      entities.MissionStatusDraft,
 			},
 		}
 
+		// Walk the missions through their lifecycle instead of faking the state
+		if err := errors.Join(
+			missions[0].AssignCat(cats[0].ID, missions[0].StartDate), // Jane
+			missions[0].Activate(),
+			missions[2].AssignCat(cats[3].ID, missions[2].StartDate), // Luna
+			missions[2].Complete(now.AddDate(0, 0, -12)),
+			missions[3].AssignCat(cats[2].ID, missions[3].StartDate), // Mittens
+			missions[3].Activate(),
+		); err != nil {
+			return err
+		}
+
 		for i := range missions {
 			if err := tx.Create(&missions[i]).Error; err != nil {
 				return err
 			}
 		}
 
+		// Record who worked on what, including Luna's finished mission
+		completedReason := entities.AssignmentEndCompleted
+		assignments := []entities.MissionAssignment{
+			*entities.NewMissionAssignment(missions[0].ID, cats[0].ID, missions[0].StartDate),
+			{MissionID: missions[2].ID, CatID: cats[3].ID, AssignedAt: missions[2].StartDate, EndedAt: missions[2].CompletedAt, EndReason: &completedReason},
+			*entities.NewMissionAssignment(missions[3].ID, cats[2].ID, missions[3].StartDate),
+		}
+		if err := tx.Create(&assignments).Error; err != nil {
+			return err
+		}
+
 		// Update cats with mission assignments
 		if err := tx.Model(&cats[0]).Update("mission_id", missions[0].ID).Error; err != nil {
 			return err
@@ -147,83 +220,55 @@ func (m *MockDataService) WipeAndSeedData() error {
 			return err
 		}
 
-		// Create targets for missions
-		targets := []entities.Target{
+		// Create targets for missions. Statuses are reached through the target
+		// state machine so seeded data obeys the same rules as the API.
+		targetSeeds := []struct {
+			missionID int32
+			name      string
+			country   string
+			status    entities.TargetStatus
+			notes     *string
+		}{
 			// Targets for Operation Goldfish (different statuses)
-			{
-				MissionID: missions[0].ID,
-				Name:      "Dr. Fisherman",
-				Country:   "Monaco",
-				Status:    "completed",
-				Notes:     stringPtr("Successfully infiltrated his office and retrieved documents."),
-			},
-			{
-				MissionID: missions[0].ID,
-				Name:      "Captain Aquarius",
-				Country:   "Greece",
-				Status:    "in_progress",
-				Notes:     stringPtr("Currently tracking his movements near the harbor."),
-			},
-			{
-				MissionID: missions[0].ID,
-				Name:      "Marina Scales",
-				Country:   "Italy",
-				Status:    "init",
-				Notes:     nil,
-			},
+			{missions[0].ID, "Dr. Fisherman", "Monaco", entities.TargetStatusCompleted, stringPtr("Successfully infiltrated his office and retrieved documents.")},
+			{missions[0].ID, "Captain Aquarius", "Greece", entities.TargetStatusInProgress, stringPtr("Currently tracking his movements near the harbor.")},
+			{missions[0].ID, "Marina Scales", "Italy", entities.TargetStatusInit, nil},
 
 			// Targets for Mission Catnip Cartel
-			{
-				MissionID: missions[1].ID,
-				Name:      "Pablo Whiskers",
-				Country:   "Colombia",
-				Status:    "init",
-				Notes:     nil,
-			},
-			{
-				MissionID: missions[1].ID,
-				Name:      "El Gato",
-				Country:   "Mexico",
-				Status:    "init",
-				Notes:     nil,
-			},
+			{missions[1].ID, "Pablo Whiskers", "Colombia", entities.TargetStatusInit, nil},
+			{missions[1].ID, "El Gato", "Mexico", entities.TargetStatusInit, nil},
 
 			// Targets for completed mission (all completed)
-			{
-				MissionID: missions[2].ID,
-				Name:      "Rodent King",
-				Country:   "USA",
-				Status:    "completed",
-				Notes:     stringPtr("Mission accomplished. Warehouse secured."),
-			},
+			{missions[2].ID, "Rodent King", "USA", entities.TargetStatusCompleted, stringPtr("Mission accomplished. Warehouse secured.")},
 
 			// Targets for Project Yarn Ball
-			{
-				MissionID: missions[3].ID,
-				Name:      "Ms. Knittington",
-				Country:   "UK",
-				Status:    "completed",
-				Notes:     stringPtr("Obtained yarn quality samples successfully."),
-			},
-			{
-				MissionID: missions[3].ID,
-				Name:      "Thread Master",
-				Country:   "India",
-				Status:    "in_progress",
-				Notes:     stringPtr("Infiltrating the textile factory as planned."),
-			},
+			{missions[3].ID, "Ms. Knittington", "UK", entities.TargetStatusCompleted, stringPtr("Obtained yarn quality samples successfully.")},
+			{missions[3].ID, "Thread Master", "India", entities.TargetStatusInProgress, stringPtr("Infiltrating the textile factory as planned.")},
 		}
 
-		for i := range targets {
-			if err := tx.Create(&targets[i]).Error; err != nil {
+		for _, seed := range targetSeeds {
+			target := entities.NewTarget(seed.missionID, seed.name, seed.country, seed.notes)
+			if seed.status == entities.TargetStatusCompleted {
+				if err := target.TransitionTo(entities.TargetStatusInProgress); err != nil {
+					return err
+				}
+			}
+			if err := target.TransitionTo(seed.status); err != nil {
+				return err
+			}
+			if err := tx.Create(target).Error; err != nil {
 				return err
 			}
 		}
 
 		log.Printf("✅ Successfully seeded:")
-		log.Printf("   - %d spy cats", len(cats))
+		if m.catPassword != "" {
+			log.Printf("   - %d spy cats with logins", len(cats))
+		} else {
+			log.Printf("   - %d spy cats without logins", len(cats))
+		}
 		log.Printf("   - %d missions (1 completed, 2 active with cats, 1 pending)", len(missions))
-		log.Printf("   - %d targets", len(targets))
+		log.Printf("   - %d targets", len(targetSeeds))
 
 		return nil
 	})
@@ -0,0 +1,153 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// Sink receives outbox events. Delivery is at-least-once, so a sink may see
+// the same event ID more than once and must tolerate that.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, event *entities.OutboxEvent) error
+}
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MaxAttempts  int32
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 2 * time.Second,
+		BatchSize:    50,
+		Lease:        time.Minute,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   10 * time.Minute,
+	}
+}
+
+// Dispatcher polls the outbox and hands each due event to every sink. An event
+// is marked delivered only when all sinks accept it; otherwise it is retried
+// with exponential backoff until MaxAttempts, after which it is marked dead
+// and waits for a manual replay. Retries only go to the sinks that have not
+// accepted the event yet.
+type Dispatcher struct {
+	repo   interfaces.OutboxRepository
+	sinks  []Sink
+	config Config
+	now    func() time.Time
+}
+
+func NewDispatcher(repo interfaces.OutboxRepository, config Config, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		sinks:  sinks,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Outbox dispatch failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce delivers one batch of due events and reports how many were
+// claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	claimed, err := d.repo.ClaimDue(ctx, d.now(), d.config.Lease, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range claimed {
+		if err := d.deliver(ctx, event); err != nil {
+			d.fail(ctx, event, err)
+			continue
+		}
+
+		if err := d.repo.MarkDelivered(ctx, event.ID, d.now()); err != nil {
+			log.Printf("Outbox event %d delivered but not marked: %v", event.ID, err)
+		}
+	}
+
+	return len(claimed), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event *entities.OutboxEvent) error {
+	var errs []error
+	for _, sink := range d.sinks {
+		if event.DeliveredTo(sink.Name()) {
+			continue
+		}
+		if err := sink.Deliver(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		event.RecordDelivery(sink.Name())
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) fail(ctx context.Context, event *entities.OutboxEvent, cause error) {
+	var err error
+	if event.Attempts >= d.config.MaxAttempts {
+		log.Printf("Outbox event %d (%s) gave up after %d attempts: %v", event.ID, event.EventName, event.Attempts, cause)
+		err = d.repo.MarkDead(ctx, event.ID, event.DeliveredSinks, cause.Error())
+	} else {
+		err = d.repo.MarkFailed(ctx, event.ID, d.now().Add(d.backoff(event.Attempts)), event.DeliveredSinks, cause.Error())
+	}
+
+	if err != nil {
+		log.Printf("Outbox event %d failed and could not be rescheduled: %v", event.ID, err)
+	}
+}
+
+// backoff doubles the delay after every attempt, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	delay := d.config.BaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// LogSink writes delivered events to the standard logger.
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Deliver(ctx context.Context, event *entities.OutboxEvent) error {
+	log.Printf("OUTBOX_EVENT: #%d %s %s", event.ID, event.EventName, event.Payload)
+	return nil
+}
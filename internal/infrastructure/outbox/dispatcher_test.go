@@ -0,0 +1,184 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// fakeRepo keeps outbox events in memory and claims them like the database
+// does: due pending events, with the attempt counted and a lease set.
+type fakeRepo struct {
+	interfaces.OutboxRepository
+	events map[int64]*entities.OutboxEvent
+}
+
+func newFakeRepo(events ...*entities.OutboxEvent) *fakeRepo {
+	repo := &fakeRepo{events: make(map[int64]*entities.OutboxEvent)}
+	for _, event := range events {
+		repo.events[event.ID] = event
+	}
+	return repo
+}
+
+func (r *fakeRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.OutboxEvent, error) {
+	var claimed []*entities.OutboxEvent
+	for _, event := range r.events {
+		if event.Status != entities.OutboxStatusPending || event.NextAttemptAt.After(now) || len(claimed) == limit {
+			continue
+		}
+		event.Attempts++
+		event.NextAttemptAt = now.Add(lease)
+		copied := *event
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (r *fakeRepo) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	r.events[id].Status = entities.OutboxStatusDelivered
+	r.events[id].DeliveredAt = &at
+	return nil
+}
+
+func (r *fakeRepo) MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, deliveredSinks, lastError string) error {
+	r.events[id].NextAttemptAt = nextAttemptAt
+	r.events[id].DeliveredSinks = deliveredSinks
+	r.events[id].LastError = &lastError
+	return nil
+}
+
+func (r *fakeRepo) MarkDead(ctx context.Context, id int64, deliveredSinks, lastError string) error {
+	r.events[id].Status = entities.OutboxStatusDead
+	r.events[id].DeliveredSinks = deliveredSinks
+	r.events[id].LastError = &lastError
+	return nil
+}
+
+// fakeSink fails while failing is set and counts every delivery attempt.
+type fakeSink struct {
+	name     string
+	failing  bool
+	attempts int
+}
+
+func (s *fakeSink) Name() string {
+	return s.name
+}
+
+func (s *fakeSink) Deliver(ctx context.Context, event *entities.OutboxEvent) error {
+	s.attempts++
+	if s.failing {
+		return errors.New("receiver unavailable")
+	}
+	return nil
+}
+
+var dispatchStart = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDispatcher(repo *fakeRepo, config Config, sinks ...Sink) *Dispatcher {
+	dispatcher := NewDispatcher(repo, config, sinks...)
+	dispatcher.now = func() time.Time { return dispatchStart }
+	return dispatcher
+}
+
+func pendingEvent(id int64, attempts int32) *entities.OutboxEvent {
+	event := entities.NewOutboxEvent("mission.created", []byte(`{}`), dispatchStart)
+	event.ID = id
+	event.Attempts = attempts
+	return event
+}
+
+func TestBackoff(t *testing.T) {
+	dispatcher := NewDispatcher(nil, Config{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	want := map[int32]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 30: 10 * time.Second}
+	for attempts, delay := range want {
+		if got := dispatcher.backoff(attempts); got != delay {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, delay)
+		}
+	}
+}
+
+func TestDispatchOnceSchedulesFailedEventWithBackoff(t *testing.T) {
+	repo := newFakeRepo(pendingEvent(1, 2))
+	sink := &fakeSink{name: "webhooks", failing: true}
+	dispatcher := newTestDispatcher(repo, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 5, BaseBackoff: time.Second, MaxBackoff: time.Minute}, sink)
+
+	claimed, err := dispatcher.DispatchOnce(context.Background())
+	if err != nil || claimed != 1 {
+		t.Fatalf("DispatchOnce = %d, %v, want 1 claimed", claimed, err)
+	}
+
+	event := repo.events[1]
+	if event.Status != entities.OutboxStatusPending || event.Attempts != 3 {
+		t.Errorf("event = %s after %d attempts, want pending after 3", event.Status, event.Attempts)
+	}
+	if want := dispatchStart.Add(4 * time.Second); !event.NextAttemptAt.Equal(want) {
+		t.Errorf("next attempt at %v, want %v", event.NextAttemptAt, want)
+	}
+	if event.LastError == nil || *event.LastError != "webhooks: receiver unavailable" {
+		t.Errorf("last error = %v, want the sink error", event.LastError)
+	}
+}
+
+func TestDispatchOnceMarksEventDeadAfterMaxAttempts(t *testing.T) {
+	repo := newFakeRepo(pendingEvent(1, 4))
+	sink := &fakeSink{name: "webhooks", failing: true}
+	dispatcher := newTestDispatcher(repo, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 5, BaseBackoff: time.Second, MaxBackoff: time.Minute}, sink)
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce: %v", err)
+	}
+
+	if event := repo.events[1]; event.Status != entities.OutboxStatusDead || event.LastError == nil {
+		t.Errorf("event = %+v, want dead with the last error", event)
+	}
+
+	if claimed, _ := dispatcher.DispatchOnce(context.Background()); claimed != 0 || sink.attempts != 1 {
+		t.Errorf("claimed = %d, sink attempts = %d after the event died, want 0 and 1", claimed, sink.attempts)
+	}
+}
+
+func TestDispatchOnceRetriesOnlyFailedSinks(t *testing.T) {
+	repo := newFakeRepo(pendingEvent(1, 0))
+	logSink := &fakeSink{name: "log"}
+	webhookSink := &fakeSink{name: "webhooks", failing: true}
+	dispatcher := newTestDispatcher(repo, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 5, BaseBackoff: time.Second, MaxBackoff: time.Minute}, logSink, webhookSink)
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce: %v", err)
+	}
+	if got := repo.events[1].DeliveredSinkNames(); !reflect.DeepEqual(got, []string{"log"}) {
+		t.Errorf("delivered sinks = %v, want [log]", got)
+	}
+
+	webhookSink.failing = false
+	dispatcher.now = func() time.Time { return dispatchStart.Add(time.Hour) }
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce: %v", err)
+	}
+
+	if logSink.attempts != 1 || webhookSink.attempts != 2 {
+		t.Errorf("log attempts = %d, webhook attempts = %d, want 1 and 2", logSink.attempts, webhookSink.attempts)
+	}
+	if event := repo.events[1]; event.Status != entities.OutboxStatusDelivered || event.DeliveredAt == nil {
+		t.Errorf("event = %+v, want delivered", event)
+	}
+}
+
+func TestReplayDeliversToEverySinkAgain(t *testing.T) {
+	event := pendingEvent(1, 5)
+	event.Status = entities.OutboxStatusDead
+	event.RecordDelivery("log")
+
+	event.Replay(dispatchStart)
+	if event.DeliveredTo("log") || event.Attempts != 0 || event.Status != entities.OutboxStatusPending {
+		t.Errorf("event = %+v after replay, want pending with no sinks delivered", event)
+	}
+}
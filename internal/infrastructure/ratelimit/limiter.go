@@ -0,0 +1,192 @@
+// Package ratelimit keeps a token bucket per client and route group in memory.
+// Buckets are per process, so each instance enforces its limits on its own.
+package ratelimit
+
+import (
+	"expvar"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Route groups with their own limits. IP covers every request by client IP
+// before its credentials are checked. Default covers the whole API; the others
+// are stricter limits on routes that are expensive or attractive to abuse.
+const (
+	GroupIP      = "ip"
+	GroupDefault = "default"
+	GroupAuth    = "auth"
+	GroupCatAPI  = "catapi"
+)
+
+// rejections counts refused requests by group and client kind, e.g.
+// "catapi.ip". It is published with the other expvars on /debug/vars.
+var rejections = expvar.NewMap("rate_limit_rejections")
+
+// Limit is a token bucket holding up to Burst requests that refills at
+// Requests per Per.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimit reads limits such as "30/m" or "30/m:10", where the number after
+// the colon is the burst. The burst defaults to the request count.
+func ParseLimit(value string) (Limit, error) {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+	count, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q must look like 30/m or 30/m:10", value)
+	}
+
+	requests, err := strconv.Atoi(count)
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("rate limit %q must allow a positive number of requests", value)
+	}
+	per, ok := periods[unit]
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q must be per s, m or h", value)
+	}
+
+	limit := Limit{Requests: requests, Per: per, Burst: requests}
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst <= 0 {
+			return Limit{}, fmt.Errorf("rate limit %q must have a positive burst", value)
+		}
+	}
+	return limit, nil
+}
+
+// perSecond is the refill rate in tokens per second.
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+type Config struct {
+	// Limits maps route groups to their limit. Groups without one are not
+	// limited.
+	Limits map[string]Limit
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Limits: map[string]Limit{
+			GroupIP:      {Requests: 600, Per: time.Minute, Burst: 120},
+			GroupDefault: {Requests: 300, Per: time.Minute, Burst: 60},
+			GroupAuth:    {Requests: 10, Per: time.Minute, Burst: 5},
+			GroupCatAPI:  {Requests: 30, Per: time.Minute, Burst: 10},
+		},
+	}
+}
+
+// Decision is the outcome of one request against its bucket.
+type Decision struct {
+	Allowed   bool
+	Limit     Limit
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long a refused client has to wait for a token.
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type bucketKey struct {
+	group  string
+	client string
+}
+
+type Limiter struct {
+	limits map[string]Limit
+	now    func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(config Config) *Limiter {
+	return &Limiter{
+		limits:  config.Limits,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Allow takes a token from the client's bucket for the group. The client kind
+// only labels the rejection metric. ok is false when the group has no limit.
+func (l *Limiter) Allow(group, clientKind, client string) (decision Decision, ok bool) {
+	limit, ok := l.limits[group]
+	if !ok {
+		return Decision{}, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	key := bucketKey{group: group, client: client}
+	b, found := l.buckets[key]
+	if !found {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(limit, now)
+
+	decision = Decision{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / limit.perSecond())
+		rejections.Add(group+"."+clientKind, 1)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.perSecond())
+	return decision, true
+}
+
+func (b *bucket) refill(limit Limit, now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.perSecond())
+		b.updated = now
+	}
+}
+
+// sweep drops buckets that have had time to refill completely, since a full
+// bucket behaves the same as a missing one. It runs at most once a minute.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		limit := l.limits[key.group]
+		b.refill(limit, now)
+		if b.tokens >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
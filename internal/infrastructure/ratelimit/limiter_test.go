@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(limits map[string]Limit) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewLimiter(Config{Limits: limits})
+	limiter.now = func() time.Time { return clock.now }
+	return limiter, clock
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Limit
+	}{
+		{"30/m", Limit{Requests: 30, Per: time.Minute, Burst: 30}},
+		{"30/m:10", Limit{Requests: 30, Per: time.Minute, Burst: 10}},
+		{" 5/s:1 ", Limit{Requests: 5, Per: time.Second, Burst: 1}},
+		{"1000/h:50", Limit{Requests: 1000, Per: time.Hour, Burst: 50}},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, %v, want %+v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "30", "30/d", "0/m", "-1/m", "x/m", "30/m:0", "30/m:x"} {
+		if _, err := ParseLimit(value); err == nil {
+			t.Errorf("ParseLimit(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestLimiterSpendsBurstThenRefills(t *testing.T) {
+	limiter, clock := newTestLimiter(map[string]Limit{"writes": {Requests: 6, Per: time.Minute, Burst: 3}})
+
+	for i := 0; i < 3; i++ {
+		decision, _ := limiter.Allow("writes", "ip", "ip:1")
+		if !decision.Allowed || decision.Remaining != 2-i {
+			t.Fatalf("request %d: %+v, want allowed with %d remaining", i, decision, 2-i)
+		}
+	}
+
+	decision, _ := limiter.Allow("writes", "ip", "ip:1")
+	if decision.Allowed {
+		t.Fatal("request past the burst was allowed")
+	}
+	if decision.RetryAfter != 10*time.Second || decision.Reset != 30*time.Second {
+		t.Errorf("retry after %v, reset %v, want 10s and 30s", decision.RetryAfter, decision.Reset)
+	}
+
+	clock.advance(10 * time.Second)
+	if decision, _ := limiter.Allow("writes", "ip", "ip:1"); !decision.Allowed {
+		t.Error("request after one refill interval was refused")
+	}
+}
+
+func TestLimiterKeepsClientsAndGroupsApart(t *testing.T) {
+	limiter, _ := newTestLimiter(map[string]Limit{
+		"a": {Requests: 1, Per: time.Minute, Burst: 1},
+		"b": {Requests: 1, Per: time.Minute, Burst: 1},
+	})
+
+	limiter.Allow("a", "ip", "ip:1")
+	if decision, _ := limiter.Allow("a", "ip", "ip:2"); !decision.Allowed {
+		t.Error("another client shared the first client's bucket")
+	}
+	if decision, _ := limiter.Allow("b", "ip", "ip:1"); !decision.Allowed {
+		t.Error("another group shared the first group's bucket")
+	}
+	if _, limited := limiter.Allow("unlimited", "ip", "ip:1"); limited {
+		t.Error("a group without a limit was limited")
+	}
+}
+
+func TestLimiterCountsRejections(t *testing.T) {
+	limiter, _ := newTestLimiter(map[string]Limit{"counted": {Requests: 1, Per: time.Minute, Burst: 1}})
+
+	limiter.Allow("counted", "api_key", "api_key:1")
+	limiter.Allow("counted", "api_key", "api_key:1")
+	limiter.Allow("counted", "api_key", "api_key:1")
+
+	if got := rejections.Get("counted.api_key"); got == nil || got.String() != "2" {
+		t.Errorf("rejections = %v, want 2", got)
+	}
+}
+
+func TestLimiterForgetsRefilledBuckets(t *testing.T) {
+	limiter, clock := newTestLimiter(map[string]Limit{"a": {Requests: 60, Per: time.Minute, Burst: 10}})
+
+	limiter.Allow("a", "ip", "ip:1")
+	clock.advance(2 * time.Minute)
+	limiter.Allow("a", "ip", "ip:2")
+
+	if _, kept := limiter.buckets[bucketKey{group: "a", client: "ip:1"}]; kept {
+		t.Error("a bucket that had refilled was kept")
+	}
+	if len(limiter.buckets) != 1 {
+		t.Errorf("buckets = %d, want only the active client's", len(limiter.buckets))
+	}
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) interfaces.AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) WithTx(tx *gorm.DB) interfaces.AuditRepository {
+	return &AuditRepository{db: tx}
+}
+
+func (r *AuditRepository) Record(ctx context.Context, entries ...*entities.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Create(&entries).Error; err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter interfaces.AuditFilter) ([]*entities.AuditEntry, error) {
+	query := r.db.WithContext(ctx).Order("created_at DESC, id DESC")
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Actor != nil {
+		query = query.Where("actor = ?", *filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(int(filter.Offset))
+	}
+
+	var entries []*entities.AuditEntry
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -2,10 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"spy-cat-agency/internal/domain/entities"
 	"spy-cat-agency/internal/domain/interfaces"
@@ -38,49 +41,198 @@ func (r *CatRepository) Create(ctx context.Context, spyCat *entities.SpyCat) (*e
 func (r *CatRepository) GetByID(ctx context.Context, id int32) (*entities.SpyCat, error) {
 	var spyCat entities.SpyCat
 	if err := r.db.WithContext(ctx).First(&spyCat, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrCatNotFound
+		}
 		return nil, fmt.Errorf("failed to get cat: %w", err)
 	}
 	return &spyCat, nil
 }
 
-func (r *CatRepository) List(ctx context.Context, limit, offset int32) ([]*entities.SpyCat, error) {
-	var spyCats []*entities.SpyCat
+// GetByIDForUpdate loads the cat and locks its row until the surrounding
+// transaction ends.
+func (r *CatRepository) GetByIDForUpdate(ctx context.Context, id int32) (*entities.SpyCat, error) {
+	var spyCat entities.SpyCat
 	if err := r.db.WithContext(ctx).
-		Order("id").
-		Limit(int(limit)).
-		Offset(int(offset)).
-		Find(&spyCats).Error; err != nil {
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&spyCat, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrCatNotFound
+		}
+		return nil, fmt.Errorf("failed to lock cat: %w", err)
+	}
+	return &spyCat, nil
+}
+
+func (r *CatRepository) List(ctx context.Context, filter interfaces.CatFilter) ([]*entities.SpyCat, error) {
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	sortBy := "id"
+	if interfaces.IsCatSortField(filter.SortBy) {
+		sortBy = filter.SortBy
+	}
+
+	query := applyCatFilter(r.db.WithContext(ctx).Model(&entities.SpyCat{}), filter).
+		Order(fmt.Sprintf("%s %s", sortBy, direction))
+	if sortBy != "id" {
+		query = query.Order("id " + direction)
+	}
+	if filter.After != nil {
+		value, err := catCursorValue(filter.After)
+		if err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition(sortBy, "id", value, filter.After)
+		query = query.Where(condition, args...)
+	} else if filter.Offset > 0 {
+		query = query.Offset(int(filter.Offset))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+
+	var spyCats []*entities.SpyCat
+	if err := query.Find(&spyCats).Error; err != nil {
 		return nil, fmt.Errorf("failed to list cats: %w", err)
 	}
 	return spyCats, nil
 }
 
-func (r *CatRepository) UpdateSalary(ctx context.Context, id int32, salary float64) (*entities.SpyCat, error) {
+func catCursorValue(cursor *interfaces.Cursor) (interface{}, error) {
+	switch cursor.SortBy {
+	case "salary":
+		salary, err := strconv.ParseFloat(cursor.Value, 64)
+		if err != nil {
+			return nil, entities.ErrInvalidCursor
+		}
+		return salary, nil
+	case "years_of_experience":
+		years, err := strconv.ParseInt(cursor.Value, 10, 32)
+		if err != nil {
+			return nil, entities.ErrInvalidCursor
+		}
+		return years, nil
+	}
+	return cursor.Value, nil
+}
+
+func (r *CatRepository) Count(ctx context.Context, filter interfaces.CatFilter) (int64, error) {
+	var count int64
+	if err := applyCatFilter(r.db.WithContext(ctx).Model(&entities.SpyCat{}), filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count cats: %w", err)
+	}
+	return count, nil
+}
+
+func applyCatFilter(query *gorm.DB, filter interfaces.CatFilter) *gorm.DB {
+	if filter.Breed != nil {
+		query = query.Where("LOWER(breed) = LOWER(?)", *filter.Breed)
+	}
+	if filter.MinExperience != nil {
+		query = query.Where("years_of_experience >= ?", *filter.MinExperience)
+	}
+	if filter.MaxExperience != nil {
+		query = query.Where("years_of_experience <= ?", *filter.MaxExperience)
+	}
+	if filter.MinSalary != nil {
+		query = query.Where("salary >= ?", *filter.MinSalary)
+	}
+	if filter.MaxSalary != nil {
+		query = query.Where("salary <= ?", *filter.MaxSalary)
+	}
+	if filter.Available != nil {
+		if *filter.Available {
+			query = query.Where("mission_id IS NULL")
+		} else {
+			query = query.Where("mission_id IS NOT NULL")
+		}
+	}
+	if filter.Name != nil {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(*filter.Name)+"%")
+	}
+	return query
+}
+
+func (r *CatRepository) UpdateSalary(ctx context.Context, id int32, salary float64, expectedVersion *int32) (*entities.SpyCat, error) {
 	var spyCat entities.SpyCat
 	if err := r.db.WithContext(ctx).First(&spyCat, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrCatNotFound
+		}
 		return nil, fmt.Errorf("failed to find cat: %w", err)
 	}
 
+	if err := entities.CheckVersion(expectedVersion, spyCat.Version); err != nil {
+		return nil, err
+	}
+
 	spyCat.UpdateSalary(salary)
-	if err := r.db.WithContext(ctx).Save(&spyCat).Error; err != nil {
-		return nil, fmt.Errorf("failed to update cat salary: %w", err)
+	result := r.db.WithContext(ctx).Model(&entities.SpyCat{}).
+		Where("id = ? AND version = ?", id, spyCat.Version).
+		Updates(map[string]interface{}{
+			"salary":     spyCat.Salary,
+			"updated_at": spyCat.UpdatedAt,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update cat salary: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, entities.ErrVersionConflict
 	}
 
+	spyCat.Version++
 	return &spyCat, nil
 }
 
-func (r *CatRepository) Delete(ctx context.Context, id int32) error {
+// UpdateProfile writes the name, breed and experience of a loaded cat, but
+// only if nobody else changed it since. The version is bumped on success.
+func (r *CatRepository) UpdateProfile(ctx context.Context, spyCat *entities.SpyCat) (*entities.SpyCat, error) {
+	result := r.db.WithContext(ctx).Model(&entities.SpyCat{}).
+		Where("id = ? AND version = ?", spyCat.ID, spyCat.Version).
+		Updates(map[string]interface{}{
+			"name":                spyCat.Name,
+			"breed":               spyCat.Breed,
+			"years_of_experience": spyCat.YearsOfExperience,
+			"updated_at":          spyCat.UpdatedAt,
+			"version":             gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update cat profile: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, entities.ErrVersionConflict
+	}
+
+	spyCat.Version++
+	return spyCat, nil
+}
+
+func (r *CatRepository) Delete(ctx context.Context, id int32, expectedVersion *int32) error {
 	var spyCat entities.SpyCat
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&spyCat).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ErrCatNotFound
+		}
 		return fmt.Errorf("failed to find cat: %w", err)
 	}
 
+	if err := entities.CheckVersion(expectedVersion, spyCat.Version); err != nil {
+		return err
+	}
+
 	if spyCat.MissionID != nil {
-		return fmt.Errorf("cannot delete cat: cat is currently assigned to mission ID %d", *spyCat.MissionID)
+		return entities.ErrCatOnMission.WithDetail(fmt.Sprintf("cannot delete cat: cat is currently assigned to mission ID %d", *spyCat.MissionID))
 	}
 
-	if err := r.db.WithContext(ctx).Delete(&entities.SpyCat{}, id).Error; err != nil {
-		return fmt.Errorf("failed to delete cat: %w", err)
+	result := r.db.WithContext(ctx).Where("version = ?", spyCat.Version).Delete(&entities.SpyCat{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete cat: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrVersionConflict
 	}
 	return nil
 }
@@ -92,6 +244,7 @@ func (r *CatRepository) AssignToMission(ctx context.Context, catID, missionID in
 		Updates(map[string]interface{}{
 			"mission_id": missionID,
 			"updated_at": now,
+			"version":    gorm.Expr("version + 1"),
 		}).Error; err != nil {
 		return fmt.Errorf("failed to assign cat to mission: %w", err)
 	}
@@ -105,6 +258,7 @@ func (r *CatRepository) UnassignFromMission(ctx context.Context, catID int32) er
 		Updates(map[string]interface{}{
 			"mission_id": nil,
 			"updated_at": now,
+			"version":    gorm.Expr("version + 1"),
 		}).Error; err != nil {
 		return fmt.Errorf("failed to unassign cat from mission: %w", err)
 	}
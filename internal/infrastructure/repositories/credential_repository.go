@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type CredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewCredentialRepository(db *gorm.DB) interfaces.CredentialRepository {
+	return &CredentialRepository{db: db}
+}
+
+func (r *CredentialRepository) WithTx(tx *gorm.DB) interfaces.CredentialRepository {
+	return &CredentialRepository{db: tx}
+}
+
+func (r *CredentialRepository) Create(ctx context.Context, credential *entities.Credential) error {
+	if err := r.db.WithContext(ctx).Create(credential).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entities.ErrUsernameTaken
+		}
+		return fmt.Errorf("failed to create credential: %w", err)
+	}
+	return nil
+}
+
+func (r *CredentialRepository) GetByUsername(ctx context.Context, username string) (*entities.Credential, error) {
+	return r.first(ctx, "username = ?", username)
+}
+
+func (r *CredentialRepository) GetByCatID(ctx context.Context, catID int32) (*entities.Credential, error) {
+	return r.first(ctx, "cat_id = ?", catID)
+}
+
+func (r *CredentialRepository) Update(ctx context.Context, credential *entities.Credential) error {
+	err := r.db.WithContext(ctx).Model(credential).Select("username", "password_hash", "updated_at").Updates(credential).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entities.ErrUsernameTaken
+		}
+		return fmt.Errorf("failed to update credential: %w", err)
+	}
+	return nil
+}
+
+func (r *CredentialRepository) first(ctx context.Context, query string, args ...interface{}) (*entities.Credential, error) {
+	var credential entities.Credential
+	err := r.db.WithContext(ctx).Where(query, args...).First(&credential).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+	return &credential, nil
+}
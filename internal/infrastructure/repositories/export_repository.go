@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type ExportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) interfaces.ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+func (r *ExportRepository) StreamCats(ctx context.Context, filter interfaces.CatFilter, fn func(*entities.SpyCat) error) error {
+	sortBy := "id"
+	if interfaces.IsCatSortField(filter.SortBy) {
+		sortBy = filter.SortBy
+	}
+
+	query := applyCatFilter(r.db.WithContext(ctx).Model(&entities.SpyCat{}), filter)
+	query = orderForExport(query, sortBy, "id", filter.SortDesc)
+
+	return streamRows(ctx, r.db, query, fn)
+}
+
+func (r *ExportRepository) StreamMissions(ctx context.Context, filter interfaces.MissionFilter, fn func(*entities.Mission) error) error {
+	sortBy := "id"
+	if interfaces.IsMissionSortField(filter.SortBy) {
+		sortBy = filter.SortBy
+	}
+
+	query := applyMissionFilter(r.db.WithContext(ctx).Model(&entities.Mission{}), filter).
+		Select("missions.*")
+	query = orderForExport(query, "missions."+sortBy, "missions.id", filter.SortDesc)
+
+	return streamRows(ctx, r.db, query, fn)
+}
+
+func (r *ExportRepository) StreamTargets(ctx context.Context, filter interfaces.MissionFilter, fn func(*entities.Target) error) error {
+	missions := applyMissionFilter(r.db.Model(&entities.Mission{}).Select("missions.id"), filter)
+
+	query := r.db.WithContext(ctx).Model(&entities.Target{}).
+		Where("targets.mission_id IN (?)", missions).
+		Order("targets.mission_id ASC").
+		Order("targets.id ASC")
+
+	return streamRows(ctx, r.db, query, fn)
+}
+
+func orderForExport(query *gorm.DB, column, idColumn string, desc bool) *gorm.DB {
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	query = query.Order(fmt.Sprintf("%s %s", column, direction))
+	if column != idColumn {
+		query = query.Order(idColumn + " " + direction)
+	}
+	return query
+}
+
+// streamRows runs query and hands each row to fn as it arrives from the
+// database cursor.
+func streamRows[T any](ctx context.Context, db *gorm.DB, query *gorm.DB, fn func(*T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to start export: %w", err)
+	}
+	defer rows.Close()
+
+	scanner := db.WithContext(ctx)
+	for rows.Next() {
+		var row T
+		if err := scanner.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("failed to read export row: %w", err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read export rows: %w", err)
+	}
+	return nil
+}
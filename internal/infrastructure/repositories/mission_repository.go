@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"spy-cat-agency/internal/domain/entities"
 	"spy-cat-agency/internal/domain/interfaces"
@@ -29,7 +30,7 @@ func (r *MissionRepository) Create(mission *entities.Mission) (*entities.Mission
 	}
 
 	missionCopy := *mission
-	missionCopy.Targets = nil 
+	missionCopy.Targets = nil
 
 	if err := r.db.Create(&missionCopy).Error; err != nil {
 		return nil, err
@@ -42,13 +43,41 @@ func (r *MissionRepository) Create(mission *entities.Mission) (*entities.Mission
 	return mission, nil
 }
 
-func (r *MissionRepository) GetAll() ([]*entities.Mission, error) {
+func (r *MissionRepository) List(filter interfaces.MissionFilter) ([]*entities.Mission, error) {
 	var missions []*entities.Mission
 
-	if err := r.db.
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	sortBy := "id"
+	if interfaces.IsMissionSortField(filter.SortBy) {
+		sortBy = filter.SortBy
+	}
+
+	query := applyMissionFilter(r.db.Model(&entities.Mission{}), filter).
+		Order(fmt.Sprintf("missions.%s %s", sortBy, direction))
+	if sortBy != "id" {
+		query = query.Order("missions.id " + direction)
+	}
+	if filter.After != nil {
+		value, err := missionCursorValue(filter.After)
+		if err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition("missions."+sortBy, "missions.id", value, filter.After)
+		query = query.Where(condition, args...)
+	} else if filter.Offset > 0 {
+		query = query.Offset(int(filter.Offset))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+
+	if err := query.
 		Preload("Cat").
 		Preload("Targets", func(db *gorm.DB) *gorm.DB {
-			return db.Order("targets.created_at ASC") 
+			return db.Order("targets.created_at ASC")
 		}).
 		Find(&missions).Error; err != nil {
 		return nil, err
@@ -57,6 +86,54 @@ func (r *MissionRepository) GetAll() ([]*entities.Mission, error) {
 	return missions, nil
 }
 
+func missionCursorValue(cursor *interfaces.Cursor) (interface{}, error) {
+	switch cursor.SortBy {
+	case "start_date", "end_date", "created_at":
+		at, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, entities.ErrInvalidCursor
+		}
+		return at, nil
+	}
+	return cursor.Value, nil
+}
+
+func (r *MissionRepository) Count(filter interfaces.MissionFilter) (int64, error) {
+	var count int64
+	if err := applyMissionFilter(r.db.Model(&entities.Mission{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func applyMissionFilter(query *gorm.DB, filter interfaces.MissionFilter) *gorm.DB {
+	if filter.Status != nil {
+		query = query.Where("missions.status = ?", *filter.Status)
+	}
+	if filter.IsCompleted != nil {
+		query = query.Where("missions.is_completed = ?", *filter.IsCompleted)
+	}
+	if filter.CatID != nil {
+		query = query.Where("missions.cat_id = ?", *filter.CatID)
+	}
+	if filter.Country != nil {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM targets WHERE targets.mission_id = missions.id AND targets.deleted_at IS NULL AND LOWER(targets.country) = LOWER(?))",
+			*filter.Country,
+		)
+	}
+	if filter.From != nil {
+		query = query.Where("missions.end_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("missions.start_date <= ?", *filter.To)
+	}
+	if filter.Name != nil {
+		query = query.Where("missions.name ILIKE ?", "%"+escapeLike(*filter.Name)+"%")
+	}
+	return query
+}
+
 func (r *MissionRepository) GetByID(id int32) (*entities.Mission, error) {
 	var mission entities.Mission
 	if err := r.db.
@@ -64,24 +141,59 @@ func (r *MissionRepository) GetByID(id int32) (*entities.Mission, error) {
 		Preload("Targets", func(db *gorm.DB) *gorm.DB {
 			return db.Order("targets.created_at ASC")
 		}).
+		Preload("Handovers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("mission_handovers.handed_over_at ASC")
+		}).
 		First(&mission, id).Error; err != nil {
-		return nil, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrMissionNotFound
+		}
+		return nil, fmt.Errorf("failed to get mission: %w", err)
 	}
 	return &mission, nil
 }
 
-func (r *MissionRepository) Delete(id int32) error {
+// GetByIDForUpdate loads the mission with its targets and locks the mission
+// row until the surrounding transaction ends.
+func (r *MissionRepository) GetByIDForUpdate(id int32) (*entities.Mission, error) {
+	var mission entities.Mission
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Targets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("targets.created_at ASC")
+		}).
+		First(&mission, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrMissionNotFound
+		}
+		return nil, fmt.Errorf("failed to lock mission: %w", err)
+	}
+	return &mission, nil
+}
+
+func (r *MissionRepository) Delete(id int32, expectedVersion *int32) error {
 	var mission entities.Mission
 	if err := r.db.Preload("Targets").First(&mission, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ErrMissionNotFound
+		}
+		return fmt.Errorf("failed to find mission: %w", err)
+	}
+
+	if err := entities.CheckVersion(expectedVersion, mission.Version); err != nil {
 		return err
 	}
 
 	if mission.CatID != nil {
-		return errors.New("cannot delete mission with assigned cat")
+		return entities.ErrMissionStaffed.WithDetail("cannot delete mission with assigned cat")
 	}
 
-	if err := r.db.Delete(&entities.Mission{}, id).Error; err != nil {
-		return fmt.Errorf("failed to delete mission: %w", err)
+	result := r.db.Where("version = ?", mission.Version).Delete(&entities.Mission{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete mission: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrVersionConflict
 	}
 
 	return nil
@@ -95,10 +207,26 @@ func (r *MissionRepository) CheckMissionExists(id int32) (bool, error) {
 	return count > 0, nil
 }
 
+// Update writes the whole mission row, but only if nobody else changed it
+// since it was loaded. The version is bumped on success.
 func (r *MissionRepository) Update(mission *entities.Mission) (*entities.Mission, error) {
-	if err := r.db.Save(mission).Error; err != nil {
-		return nil, err
+	currentVersion := mission.Version
+	mission.Version = currentVersion + 1
+
+	result := r.db.Model(mission).
+		Omit(clause.Associations).
+		Where("version = ?", currentVersion).
+		Select("*").
+		Updates(mission)
+	if result.Error != nil {
+		mission.Version = currentVersion
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		mission.Version = currentVersion
+		return nil, entities.ErrVersionConflict
 	}
+
 	return mission, nil
 }
 
@@ -128,3 +256,75 @@ func (r *MissionRepository) GetFreeCats() ([]*entities.SpyCat, error) {
 	}
 	return cats, nil
 }
+
+func (r *MissionRepository) CreateHandover(handover *entities.MissionHandover) error {
+	if err := r.db.Create(handover).Error; err != nil {
+		return fmt.Errorf("failed to record mission handover: %w", err)
+	}
+	return nil
+}
+
+func (r *MissionRepository) OpenAssignment(assignment *entities.MissionAssignment) error {
+	if err := r.db.Create(assignment).Error; err != nil {
+		return fmt.Errorf("failed to record mission assignment: %w", err)
+	}
+	return nil
+}
+
+func (r *MissionRepository) CloseAssignment(missionID int32, reason entities.AssignmentEndReason, at time.Time) error {
+	if err := r.db.Model(&entities.MissionAssignment{}).
+		Where("mission_id = ? AND ended_at IS NULL", missionID).
+		Updates(map[string]interface{}{
+			"ended_at":   at,
+			"end_reason": reason,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to close mission assignment: %w", err)
+	}
+	return nil
+}
+
+// DeleteHistory removes the mission's assignments and handovers, which would
+// otherwise keep the mission from being deleted.
+func (r *MissionRepository) DeleteHistory(missionID int32) error {
+	if err := r.db.Where("mission_id = ?", missionID).Delete(&entities.MissionHandover{}).Error; err != nil {
+		return fmt.Errorf("failed to delete mission handovers: %w", err)
+	}
+	if err := r.db.Where("mission_id = ?", missionID).Delete(&entities.MissionAssignment{}).Error; err != nil {
+		return fmt.Errorf("failed to delete mission assignments: %w", err)
+	}
+	return nil
+}
+
+func (r *MissionRepository) ListAssignments(filter interfaces.MissionAssignmentFilter) ([]*entities.MissionAssignment, error) {
+	query := r.db.Where("cat_id = ?", filter.CatID).
+		Order("assigned_at DESC").
+		Order("id DESC")
+
+	if filter.After != nil {
+		at, err := time.Parse(time.RFC3339Nano, filter.After.Value)
+		if err != nil {
+			return nil, entities.ErrInvalidCursor
+		}
+		condition, args := keysetCondition("assigned_at", "id", at, filter.After)
+		query = query.Where(condition, args...)
+	} else if filter.Offset > 0 {
+		query = query.Offset(int(filter.Offset))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+
+	var assignments []*entities.MissionAssignment
+	if err := query.Preload("Mission").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *MissionRepository) CountAssignments(catID int32) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entities.MissionAssignment{}).Where("cat_id = ?", catID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) interfaces.OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) WithTx(tx *gorm.DB) interfaces.OutboxRepository {
+	return &OutboxRepository{db: tx}
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, published ...events.Event) error {
+	if len(published) == 0 {
+		return nil
+	}
+
+	rows := make([]*entities.OutboxEvent, len(published))
+	for i, event := range published {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.EventName(), err)
+		}
+		rows[i] = entities.NewOutboxEvent(event.EventName(), payload, event.OccurredAt())
+	}
+
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to write outbox events: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.OutboxEvent, error) {
+	var claimed []*entities.OutboxEvent
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.OutboxStatusPending, now).
+			Order("id").
+			Limit(limit).
+			Find(&claimed).Error; err != nil {
+			return err
+		}
+
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(claimed))
+		leasedUntil := now.Add(lease)
+		for i, event := range claimed {
+			ids[i] = event.ID
+			event.Attempts++
+			event.NextAttemptAt = leasedUntil
+		}
+
+		return tx.Model(&entities.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": leasedUntil,
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":       entities.OutboxStatusDelivered,
+		"delivered_at": at,
+		"last_error":   nil,
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, deliveredSinks, lastError string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"next_attempt_at": nextAttemptAt,
+		"delivered_sinks": deliveredSinks,
+		"last_error":      lastError,
+	})
+}
+
+func (r *OutboxRepository) MarkDead(ctx context.Context, id int64, deliveredSinks, lastError string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":          entities.OutboxStatusDead,
+		"delivered_sinks": deliveredSinks,
+		"last_error":      lastError,
+	})
+}
+
+func (r *OutboxRepository) List(ctx context.Context, filter interfaces.OutboxFilter) ([]*entities.OutboxEvent, error) {
+	query := r.db.WithContext(ctx).Order("id DESC")
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(int(filter.Offset))
+	}
+
+	var outboxEvents []*entities.OutboxEvent
+	if err := query.Find(&outboxEvents).Error; err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+	return outboxEvents, nil
+}
+
+func (r *OutboxRepository) Replay(ctx context.Context, id int64) (*entities.OutboxEvent, error) {
+	var event entities.OutboxEvent
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entities.ErrOutboxEventNotFound
+			}
+			return err
+		}
+
+		event.Replay(time.Now())
+		return tx.Save(&event).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *OutboxRepository) update(ctx context.Context, id int64, columns map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).Where("id = ?", id).Updates(columns)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update outbox event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrOutboxEventNotFound
+	}
+	return nil
+}
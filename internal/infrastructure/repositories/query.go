@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+const uniqueViolation = "23505"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes user input match literally inside a LIKE pattern.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// keysetCondition continues a listing ordered by column and then idColumn, both
+// in the cursor's direction, strictly after the cursor row.
+func keysetCondition(column, idColumn string, value interface{}, cursor *interfaces.Cursor) (string, []interface{}) {
+	op := ">"
+	if cursor.Desc {
+		op = "<"
+	}
+	if cursor.SortBy == "id" {
+		return fmt.Sprintf("%s %s ?", idColumn, op), []interface{}{cursor.ID}
+	}
+	return fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, op), []interface{}{value, cursor.ID}
+}
+
+// isUniqueViolation reports whether err is Postgres rejecting a duplicate key.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
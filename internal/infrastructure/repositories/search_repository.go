@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+// headlineOptions marks matches for the UI. The source text is HTML escaped
+// before ts_headline runs, so the <mark> tags are the only markup in a snippet.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+const missionHits = `
+	SELECT 'mission' AS type, m.id AS id, m.id AS mission_id, m.name AS title,
+		ts_rank(m.search_vector, q.query) AS rank,
+		ts_headline('english', search_escape_html(concat_ws(' — ', m.name, m.description)), q.query, '` + headlineOptions + `') AS snippet
+	FROM missions m, q
+	WHERE m.search_vector @@ q.query`
+
+const targetHits = `
+	SELECT 'target' AS type, t.id AS id, t.mission_id AS mission_id, t.name AS title,
+		ts_rank(t.search_vector, q.query) AS rank,
+		ts_headline('english', search_escape_html(concat_ws(' — ', t.name, t.country, t.notes)), q.query, '` + headlineOptions + `') AS snippet
+	FROM targets t, q
+	WHERE t.deleted_at IS NULL AND t.search_vector @@ q.query`
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) interfaces.SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query interfaces.SearchQuery) ([]interfaces.SearchHit, error) {
+	sql := searchSQL(query.Types, "SELECT type, id, mission_id, title, snippet, rank FROM hits ORDER BY rank DESC, type, id")
+	args := []interface{}{query.Text}
+	if query.Limit > 0 {
+		sql += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+	if query.Offset > 0 {
+		sql += " OFFSET ?"
+		args = append(args, query.Offset)
+	}
+
+	var hits []interfaces.SearchHit
+	if err := r.db.WithContext(ctx).Raw(sql, args...).Scan(&hits).Error; err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	return hits, nil
+}
+
+func (r *SearchRepository) Count(ctx context.Context, query interfaces.SearchQuery) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(searchSQL(query.Types, "SELECT count(*) FROM hits"), query.Text).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+	return count, nil
+}
+
+func searchSQL(types []interfaces.SearchResultType, outer string) string {
+	var parts []string
+	if includesType(types, interfaces.SearchResultMission) {
+		parts = append(parts, missionHits)
+	}
+	if includesType(types, interfaces.SearchResultTarget) {
+		parts = append(parts, targetHits)
+	}
+
+	return "WITH q AS (SELECT websearch_to_tsquery('english', ?) AS query), hits AS (" +
+		strings.Join(parts, "\n\tUNION ALL") + "\n) " + outer
+}
+
+func includesType(types []interfaces.SearchResultType, want interfaces.SearchResultType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
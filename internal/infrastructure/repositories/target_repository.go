@@ -2,6 +2,9 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
 	"gorm.io/gorm"
 
 	"spy-cat-agency/internal/domain/entities"
@@ -47,30 +50,75 @@ func (r *TargetRepository) GetByMissionID(ctx context.Context, missionID int32)
 func (r *TargetRepository) GetByID(ctx context.Context, id int32) (*entities.Target, error) {
 	var target entities.Target
 	if err := r.db.WithContext(ctx).First(&target, id).Error; err != nil {
-		return nil, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrTargetNotFound
+		}
+		return nil, fmt.Errorf("failed to get target: %w", err)
 	}
 	return &target, nil
 }
 
+// Update writes the whole target row, but only if nobody else changed it
+// since it was loaded. The version is bumped on success.
 func (r *TargetRepository) Update(ctx context.Context, target *entities.Target) (*entities.Target, error) {
-	if err := r.db.WithContext(ctx).Save(target).Error; err != nil {
-		return nil, err
+	currentVersion := target.Version
+	target.Version = currentVersion + 1
+
+	result := r.db.WithContext(ctx).Model(target).
+		Where("version = ?", currentVersion).
+		Select("*").
+		Updates(target)
+	if result.Error != nil {
+		target.Version = currentVersion
+		return nil, result.Error
 	}
+	if result.RowsAffected == 0 {
+		target.Version = currentVersion
+		return nil, entities.ErrVersionConflict
+	}
+
 	return target, nil
 }
 
-func (r *TargetRepository) Delete(ctx context.Context, id int32) error {
-	return r.db.WithContext(ctx).Delete(&entities.Target{}, id).Error
+func (r *TargetRepository) Delete(ctx context.Context, target *entities.Target) error {
+	result := r.db.WithContext(ctx).Where("version = ?", target.Version).Delete(&entities.Target{}, target.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrVersionConflict
+	}
+	return nil
 }
 
 func (r *TargetRepository) DeleteByMissionID(ctx context.Context, missionID int32) error {
 	return r.db.WithContext(ctx).Unscoped().Where("mission_id = ?", missionID).Delete(&entities.Target{}).Error
 }
 
-func (r *TargetRepository) UpdateStatus(ctx context.Context, id int32, status entities.TargetStatus) error {
-	return r.db.WithContext(ctx).Model(&entities.Target{}).Where("id = ?", id).Update("status", status).Error
+func (r *TargetRepository) UpdateStatus(ctx context.Context, target *entities.Target) error {
+	return r.updateColumns(ctx, target, map[string]interface{}{"status": target.Status})
+}
+
+// UpdateNotes also refreshes the target's search vector, which Postgres derives
+// from the notes column.
+func (r *TargetRepository) UpdateNotes(ctx context.Context, target *entities.Target) error {
+	return r.updateColumns(ctx, target, map[string]interface{}{"notes": target.Notes})
 }
 
-func (r *TargetRepository) UpdateNotes(ctx context.Context, id int32, notes string) error {
-	return r.db.WithContext(ctx).Model(&entities.Target{}).Where("id = ?", id).Update("notes", notes).Error
+// updateColumns is a compare-and-swap on the target version.
+func (r *TargetRepository) updateColumns(ctx context.Context, target *entities.Target, columns map[string]interface{}) error {
+	columns["version"] = gorm.Expr("version + 1")
+
+	result := r.db.WithContext(ctx).Model(&entities.Target{}).
+		Where("id = ? AND version = ?", target.ID, target.Version).
+		Updates(columns)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrVersionConflict
+	}
+
+	target.Version++
+	return nil
 }
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) WithTx(tx *gorm.DB) interfaces.WebhookRepository {
+	return &WebhookRepository{db: tx}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	if err := r.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int32) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	var subscriptions []*entities.WebhookSubscription
+	if err := r.db.WithContext(ctx).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	var subscriptions []*entities.WebhookSubscription
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	result := r.db.WithContext(ctx).Model(subscription).Select("url", "event_filter", "description", "active", "updated_at").Updates(subscription)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int32) error {
+	result := r.db.WithContext(ctx).Delete(&entities.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, subscriptionID int32, outboxEventID int64) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ? AND outbox_event_id = ?", subscriptionID, outboxEventID).
+		First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	if err := r.db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int32, limit, offset int32) ([]*entities.WebhookDelivery, error) {
+	var deliveries []*entities.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
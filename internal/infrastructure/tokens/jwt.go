@@ -0,0 +1,90 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+)
+
+type Config struct {
+	Secret []byte
+	Issuer string
+	TTL    time.Duration
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Username string    `json:"username"`
+	Role     auth.Role `json:"role"`
+	CatID    *int32    `json:"cat_id,omitempty"`
+}
+
+// JWTIssuer signs HS256 access tokens. The credential ID is the subject; the
+// rest of the principal travels in private claims so requests need no lookup.
+type JWTIssuer struct {
+	config Config
+	now    func() time.Time
+}
+
+func NewJWTIssuer(config Config) (*JWTIssuer, error) {
+	if len(config.Secret) < 32 {
+		return nil, errors.New("JWT secret must be at least 32 bytes")
+	}
+	return &JWTIssuer{config: config, now: time.Now}, nil
+}
+
+func (i *JWTIssuer) Issue(principal *auth.Principal) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(i.config.TTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.config.Issuer,
+			Subject:   strconv.FormatInt(int64(principal.CredentialID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Username: principal.Username,
+		Role:     principal.Role,
+		CatID:    principal.CatID,
+	})
+
+	signed, err := token.SignedString(i.config.Secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+func (i *JWTIssuer) Verify(token string) (*auth.Principal, error) {
+	var parsed claims
+	_, err := jwt.ParseWithClaims(token, &parsed, func(*jwt.Token) (interface{}, error) {
+		return i.config.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.config.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return nil, entities.ErrInvalidToken
+	}
+
+	credentialID, err := strconv.ParseInt(parsed.Subject, 10, 32)
+	if err != nil || !parsed.Role.IsValid() || (parsed.Role == auth.RoleCat && parsed.CatID == nil) {
+		return nil, entities.ErrInvalidToken
+	}
+
+	return &auth.Principal{
+		CredentialID: int32(credentialID),
+		Username:     parsed.Username,
+		Role:         parsed.Role,
+		CatID:        parsed.CatID,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"spy-cat-agency/internal/domain/auth"
+	"spy-cat-agency/internal/domain/entities"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestIssuer(t *testing.T, issuer string) *JWTIssuer {
+	t.Helper()
+	i, err := NewJWTIssuer(Config{Secret: testSecret, Issuer: issuer, TTL: 15 * time.Minute})
+	if err != nil {
+		t.Fatalf("NewJWTIssuer: %v", err)
+	}
+	return i
+}
+
+func catPrincipal(catID int32) *auth.Principal {
+	return &auth.Principal{CredentialID: 3, Username: "whiskers", Role: auth.RoleCat, CatID: &catID}
+}
+
+// signClaims signs hand-made claims, for tokens Issue would never produce.
+func signClaims(t *testing.T, method jwt.SigningMethod, key any, c claims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(method, c).SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func validClaims() claims {
+	now := time.Now()
+	return claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "spy-cat-agency",
+			Subject:   "3",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		Username: "whiskers",
+		Role:     auth.RoleCat,
+	}
+}
+
+func assertInvalidToken(t *testing.T, issuer *JWTIssuer, token string) {
+	t.Helper()
+	principal, err := issuer.Verify(token)
+	if !errors.Is(err, entities.ErrInvalidToken) {
+		t.Errorf("Verify error = %v, want ErrInvalidToken", err)
+	}
+	if principal != nil {
+		t.Errorf("Verify principal = %+v, want none", principal)
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	issuer := newTestIssuer(t, "spy-cat-agency")
+
+	token, _, err := issuer.Issue(catPrincipal(7))
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	principal, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if principal.CredentialID != 3 || principal.Role != auth.RoleCat || !principal.IsCat(7) {
+		t.Errorf("principal = %+v, want credential 3 as cat 7", principal)
+	}
+}
+
+func TestVerifyRejectsExpiredToken(t *testing.T) {
+	issuer := newTestIssuer(t, "spy-cat-agency")
+	token, _, err := issuer.Issue(catPrincipal(7))
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	issuer.now = func() time.Time { return time.Now().Add(16 * time.Minute) }
+	assertInvalidToken(t, issuer, token)
+}
+
+func TestVerifyRejectsTokenWithoutExpiry(t *testing.T) {
+	c := validClaims()
+	c.CatID = new(int32)
+	c.ExpiresAt = nil
+
+	assertInvalidToken(t, newTestIssuer(t, "spy-cat-agency"), signClaims(t, jwt.SigningMethodHS256, testSecret, c))
+}
+
+func TestVerifyRejectsWrongIssuer(t *testing.T) {
+	token, _, err := newTestIssuer(t, "someone-else").Issue(catPrincipal(7))
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	assertInvalidToken(t, newTestIssuer(t, "spy-cat-agency"), token)
+}
+
+func TestVerifyAcceptsOnlyHS256(t *testing.T) {
+	issuer := newTestIssuer(t, "spy-cat-agency")
+	c := validClaims()
+	c.CatID = new(int32)
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		key    any
+	}{
+		{name: "HS384", method: jwt.SigningMethodHS384, key: testSecret},
+		{name: "HS512", method: jwt.SigningMethodHS512, key: testSecret},
+		{name: "none", method: jwt.SigningMethodNone, key: jwt.UnsafeAllowNoneSignatureType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertInvalidToken(t, issuer, signClaims(t, tt.method, tt.key, c))
+		})
+	}
+}
+
+func TestVerifyRejectsTamperedToken(t *testing.T) {
+	issuer := newTestIssuer(t, "spy-cat-agency")
+	token, _, err := issuer.Issue(catPrincipal(7))
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	t.Run("signature", func(t *testing.T) {
+		signature := []byte(parts[2])
+		if signature[0] == 'A' {
+			signature[0] = 'B'
+		} else {
+			signature[0] = 'A'
+		}
+		assertInvalidToken(t, issuer, strings.Join([]string{parts[0], parts[1], string(signature)}, "."))
+	})
+
+	t.Run("claims", func(t *testing.T) {
+		c := validClaims()
+		c.Role = auth.RoleDirector
+		forged := strings.Split(signClaims(t, jwt.SigningMethodHS256, testSecret, c), ".")
+		assertInvalidToken(t, issuer, strings.Join([]string{parts[0], forged[1], parts[2]}, "."))
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		c := validClaims()
+		c.CatID = new(int32)
+		assertInvalidToken(t, issuer, signClaims(t, jwt.SigningMethodHS256, []byte("fedcba9876543210fedcba9876543210"), c))
+	})
+}
+
+func TestVerifyRejectsCatTokenWithoutCatID(t *testing.T) {
+	assertInvalidToken(t, newTestIssuer(t, "spy-cat-agency"), signClaims(t, jwt.SigningMethodHS256, testSecret, validClaims()))
+}
@@ -0,0 +1,158 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+const (
+	HeaderEvent     = "X-SpyCat-Event"
+	HeaderDelivery  = "X-SpyCat-Delivery"
+	HeaderTimestamp = "X-SpyCat-Timestamp"
+	HeaderSignature = "X-SpyCat-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Payload is the JSON body posted to webhook subscribers. ID is the outbox
+// event ID and stays the same across retries, so receivers can deduplicate.
+type Payload struct {
+	ID         int64           `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sink posts outbox events to every matching webhook subscription. It plugs
+// into the outbox dispatcher, which retries failed events with backoff; a
+// subscription that already accepted an event is never sent it again.
+type Sink struct {
+	repo   interfaces.WebhookRepository
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSink(repo interfaces.WebhookRepository, client *http.Client) *Sink {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sink{
+		repo:   repo,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (s *Sink) Name() string {
+	return "webhooks"
+}
+
+func (s *Sink) Deliver(ctx context.Context, event *entities.OutboxEvent) error {
+	subscriptions, err := s.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.EventName) {
+			continue
+		}
+		if err := s.deliverTo(ctx, subscription, event); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %d: %w", subscription.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Sink) deliverTo(ctx context.Context, subscription *entities.WebhookSubscription, event *entities.OutboxEvent) error {
+	delivery, err := s.repo.GetDelivery(ctx, subscription.ID, event.ID)
+	if err != nil {
+		return err
+	}
+	if delivery != nil && delivery.Succeeded() {
+		return nil
+	}
+	if delivery == nil {
+		delivery = &entities.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			OutboxEventID:  event.ID,
+			EventName:      event.EventName,
+		}
+	}
+
+	responseStatus, sendErr := s.send(ctx, subscription, event)
+	delivery.RecordAttempt(s.now(), responseStatus, sendErr)
+
+	if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	return sendErr
+}
+
+func (s *Sink) send(ctx context.Context, subscription *entities.WebhookSubscription, event *entities.OutboxEvent) (*int, error) {
+	body, err := json.Marshal(Payload{
+		ID:         event.ID,
+		Event:      event.EventName,
+		OccurredAt: event.OccurredAt,
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	timestamp := s.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SpyCatAgency-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, event.EventName)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("receiver responded with %d", status)
+	}
+	return &status, nil
+}
+
+// Sign returns the X-SpyCat-Signature value for a body: the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the subscription secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,261 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/domain/entities"
+	"spy-cat-agency/internal/domain/events"
+	"spy-cat-agency/internal/domain/interfaces"
+)
+
+type fakeWebhookRepo struct {
+	interfaces.WebhookRepository
+	subscriptions []*entities.WebhookSubscription
+	deliveries    map[[2]int64]*entities.WebhookDelivery
+}
+
+func newFakeWebhookRepo(subscriptions ...*entities.WebhookSubscription) *fakeWebhookRepo {
+	return &fakeWebhookRepo{
+		subscriptions: subscriptions,
+		deliveries:    make(map[[2]int64]*entities.WebhookDelivery),
+	}
+}
+
+func (r *fakeWebhookRepo) ListActiveSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	var active []*entities.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.Active {
+			active = append(active, subscription)
+		}
+	}
+	return active, nil
+}
+
+func (r *fakeWebhookRepo) GetDelivery(ctx context.Context, subscriptionID int32, outboxEventID int64) (*entities.WebhookDelivery, error) {
+	delivery, ok := r.deliveries[[2]int64{int64(subscriptionID), outboxEventID}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepo) SaveDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	copied := *delivery
+	r.deliveries[[2]int64{int64(delivery.SubscriptionID), delivery.OutboxEventID}] = &copied
+	return nil
+}
+
+func (r *fakeWebhookRepo) delivery(subscriptionID int32, outboxEventID int64) *entities.WebhookDelivery {
+	return r.deliveries[[2]int64{int64(subscriptionID), outboxEventID}]
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a local webhook endpoint that answers with the queued status
+// codes in order, then 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+	server   *httptest.Server
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func newSubscription(id int32, url string, eventNames ...string) *entities.WebhookSubscription {
+	subscription := entities.NewWebhookSubscription(url, "test-secret-0123456789", eventNames, nil)
+	subscription.ID = id
+	return subscription
+}
+
+func newOutboxEvent(id int64, name string) *entities.OutboxEvent {
+	event := entities.NewOutboxEvent(name, []byte(`{"mission_id":1,"target_id":2}`), time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	event.ID = id
+	return event
+}
+
+func TestSinkSignsPayload(t *testing.T) {
+	rcv := newReceiver(t)
+	subscription := newSubscription(1, rcv.server.URL)
+	sink := NewSink(newFakeWebhookRepo(subscription), rcv.server.Client())
+
+	if err := sink.Deliver(context.Background(), newOutboxEvent(42, events.NameTargetStatusChanged)); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+
+	requests := rcv.received()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+
+	if got := req.header.Get(HeaderEvent); got != events.NameTargetStatusChanged {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, events.NameTargetStatusChanged)
+	}
+	if got := req.header.Get(HeaderDelivery); got != "42" {
+		t.Errorf("%s = %q, want 42", HeaderDelivery, got)
+	}
+
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s header: %v", HeaderTimestamp, err)
+	}
+	if !Verify(subscription.Secret, timestamp, req.body, req.header.Get(HeaderSignature)) {
+		t.Errorf("signature %q does not verify", req.header.Get(HeaderSignature))
+	}
+	if Verify("another-secret-0123456789", timestamp, req.body, req.header.Get(HeaderSignature)) {
+		t.Error("signature verified with the wrong secret")
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.ID != 42 || payload.Event != events.NameTargetStatusChanged || string(payload.Data) != `{"mission_id":1,"target_id":2}` {
+		t.Errorf("payload = %+v, want event 42 with the outbox data", payload)
+	}
+}
+
+func TestSinkOnlyDeliversMatchingEvents(t *testing.T) {
+	rcv := newReceiver(t)
+	repo := newFakeWebhookRepo(newSubscription(1, rcv.server.URL, events.NameMissionCompleted))
+	sink := NewSink(repo, rcv.server.Client())
+
+	if err := sink.Deliver(context.Background(), newOutboxEvent(1, events.NameCatCreated)); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	if err := sink.Deliver(context.Background(), newOutboxEvent(2, events.NameMissionCompleted)); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+
+	requests := rcv.received()
+	if len(requests) != 1 || requests[0].header.Get(HeaderEvent) != events.NameMissionCompleted {
+		t.Fatalf("receiver got %d requests, want only the %s event", len(requests), events.NameMissionCompleted)
+	}
+	if repo.delivery(1, 1) != nil {
+		t.Error("a delivery was logged for an event the subscription does not listen to")
+	}
+}
+
+func TestSinkSkipsInactiveSubscriptions(t *testing.T) {
+	rcv := newReceiver(t)
+	subscription := newSubscription(1, rcv.server.URL)
+	subscription.Active = false
+	sink := NewSink(newFakeWebhookRepo(subscription), rcv.server.Client())
+
+	if err := sink.Deliver(context.Background(), newOutboxEvent(1, events.NameMissionCreated)); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	if got := len(rcv.received()); got != 0 {
+		t.Errorf("receiver got %d requests for a paused subscription, want 0", got)
+	}
+}
+
+func TestSinkRetriesFailedDeliveryAndLogsAttempts(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError)
+	repo := newFakeWebhookRepo(newSubscription(1, rcv.server.URL))
+	sink := NewSink(repo, rcv.server.Client())
+	event := newOutboxEvent(7, events.NameMissionCompleted)
+
+	if err := sink.Deliver(context.Background(), event); err == nil {
+		t.Fatal("Deliver succeeded, want error so the outbox retries the event")
+	}
+
+	failed := repo.delivery(1, 7)
+	if failed == nil || failed.Status != entities.WebhookDeliveryFailed || failed.Attempts != 1 {
+		t.Fatalf("delivery log after failure = %+v, want one failed attempt", failed)
+	}
+	if failed.ResponseStatus == nil || *failed.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("logged response status = %v, want 500", failed.ResponseStatus)
+	}
+
+	if err := sink.Deliver(context.Background(), event); err != nil {
+		t.Fatalf("retry returned error: %v", err)
+	}
+
+	succeeded := repo.delivery(1, 7)
+	if succeeded.Status != entities.WebhookDeliverySucceeded || succeeded.Attempts != 2 || succeeded.DeliveredAt == nil {
+		t.Errorf("delivery log after retry = %+v, want succeeded after 2 attempts", succeeded)
+	}
+	if succeeded.LastError != nil {
+		t.Errorf("last error = %q after success, want nil", *succeeded.LastError)
+	}
+}
+
+func TestSinkDoesNotResendToSubscriptionThatAccepted(t *testing.T) {
+	healthy := newReceiver(t)
+	failing := newReceiver(t, http.StatusBadGateway)
+	repo := newFakeWebhookRepo(
+		newSubscription(1, healthy.server.URL),
+		newSubscription(2, failing.server.URL),
+	)
+	sink := NewSink(repo, http.DefaultClient)
+	event := newOutboxEvent(9, events.NameMissionAborted)
+
+	if err := sink.Deliver(context.Background(), event); err == nil {
+		t.Fatal("Deliver succeeded, want error from the failing subscription")
+	}
+	if err := sink.Deliver(context.Background(), event); err != nil {
+		t.Fatalf("retry returned error: %v", err)
+	}
+
+	if got := len(healthy.received()); got != 1 {
+		t.Errorf("healthy receiver got %d requests, want exactly 1", got)
+	}
+	if got := len(failing.received()); got != 2 {
+		t.Errorf("failing receiver got %d requests, want 2", got)
+	}
+}
+
+func TestSinkReportsUnreachableReceiver(t *testing.T) {
+	rcv := newReceiver(t)
+	url := rcv.server.URL
+	rcv.server.Close()
+
+	repo := newFakeWebhookRepo(newSubscription(1, url))
+	sink := NewSink(repo, &http.Client{Timeout: time.Second})
+
+	if err := sink.Deliver(context.Background(), newOutboxEvent(3, events.NameCatDeleted)); err == nil {
+		t.Fatal("Deliver succeeded against a closed receiver")
+	}
+
+	delivery := repo.delivery(1, 3)
+	if delivery == nil || delivery.Status != entities.WebhookDeliveryFailed || delivery.LastError == nil {
+		t.Errorf("delivery log = %+v, want a failed attempt with an error", delivery)
+	}
+}